@@ -0,0 +1,237 @@
+/*
+Copyright 2015 Palm Stone Games, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package polymer
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/gopherjs/gopherjs/js"
+)
+
+// Push appends items to the slice at path, and returns the new length of the slice
+// Polymer is told about the added items through its array mutation API, so dom-repeat only stamps the new items
+func (p *Proto) Push(path string, items ...interface{}) int {
+	sliceVal := p.sliceForPath(path)
+	itemVals := itemValues(path, sliceVal, items)
+	p.callArrayMethod("push", path, encodeItems(items)...)
+	spliceValues(sliceVal, sliceVal.Len(), 0, itemVals)
+	p.refreshSnapshot(path)
+
+	return sliceVal.Len()
+}
+
+// Pop removes the last item of the slice at path and returns it, or nil if the slice is empty
+func (p *Proto) Pop(path string) interface{} {
+	sliceVal := p.sliceForPath(path)
+	if sliceVal.Len() == 0 {
+		return nil
+	}
+
+	p.callArrayMethod("pop", path)
+	removed := spliceValues(sliceVal, sliceVal.Len()-1, 1, nil)
+	p.refreshSnapshot(path)
+
+	return removed.Index(0).Interface()
+}
+
+// Shift removes the first item of the slice at path and returns it, or nil if the slice is empty
+func (p *Proto) Shift(path string) interface{} {
+	sliceVal := p.sliceForPath(path)
+	if sliceVal.Len() == 0 {
+		return nil
+	}
+
+	p.callArrayMethod("shift", path)
+	removed := spliceValues(sliceVal, 0, 1, nil)
+	p.refreshSnapshot(path)
+
+	return removed.Index(0).Interface()
+}
+
+// Unshift inserts items at the start of the slice at path, and returns the new length of the slice
+func (p *Proto) Unshift(path string, items ...interface{}) int {
+	sliceVal := p.sliceForPath(path)
+	itemVals := itemValues(path, sliceVal, items)
+	p.callArrayMethod("unshift", path, encodeItems(items)...)
+	spliceValues(sliceVal, 0, 0, itemVals)
+	p.refreshSnapshot(path)
+
+	return sliceVal.Len()
+}
+
+// Splice removes removeCount items starting at index from the slice at path, inserts items in their place and returns the removed items as a slice
+// index and removeCount are interpreted the same way as javascript's Array.prototype.splice does, so a negative index counts from the end
+func (p *Proto) Splice(path string, index, removeCount int, items ...interface{}) interface{} {
+	sliceVal := p.sliceForPath(path)
+	index, removeCount = clampSplice(sliceVal.Len(), index, removeCount)
+	itemVals := itemValues(path, sliceVal, items)
+
+	args := append([]interface{}{index, removeCount}, encodeItems(items)...)
+	p.callArrayMethod("splice", path, args...)
+	removed := spliceValues(sliceVal, index, removeCount, itemVals)
+	p.refreshSnapshot(path)
+
+	return removed.Interface()
+}
+
+// sliceForPath returns the Go slice at path, panicking if the value at path isn't a slice
+func (p *Proto) sliceForPath(path string) reflect.Value {
+	sliceVal := getRefValForPath(p.self, strings.Split(path, "."))
+	if sliceVal.Kind() != reflect.Slice {
+		panic(fmt.Sprintf("Path '%v' is a %v, array mutations can only be done on slices", path, sliceVal.Kind()))
+	}
+
+	return sliceVal
+}
+
+// itemValues converts items to values that can be stored in sliceVal, panicking if an item has the wrong type
+func itemValues(path string, sliceVal reflect.Value, items []interface{}) []reflect.Value {
+	elemType := sliceVal.Type().Elem()
+	itemVals := make([]reflect.Value, len(items))
+	for i, item := range items {
+		itemVals[i] = reflect.ValueOf(item)
+		if !itemVals[i].IsValid() {
+			itemVals[i] = reflect.Zero(elemType)
+		}
+		if !itemVals[i].Type().AssignableTo(elemType) {
+			panic(fmt.Sprintf("Item of type %T can't be added to '%v', which holds items of type %v", item, path, elemType))
+		}
+	}
+
+	return itemVals
+}
+
+// spliceValues replaces removeCount items of sliceVal starting at index by items and returns the removed items
+// A new backing array is always allocated, so slices previously handed out (e.g. to observers) aren't affected
+func spliceValues(sliceVal reflect.Value, index, removeCount int, items []reflect.Value) reflect.Value {
+	newLen := sliceVal.Len() - removeCount + len(items)
+
+	removed := reflect.MakeSlice(sliceVal.Type(), removeCount, removeCount)
+	reflect.Copy(removed, sliceVal.Slice(index, index+removeCount))
+
+	newSlice := reflect.MakeSlice(sliceVal.Type(), newLen, newLen)
+	reflect.Copy(newSlice, sliceVal.Slice(0, index))
+	for i, item := range items {
+		newSlice.Index(index + i).Set(item)
+	}
+	reflect.Copy(newSlice.Slice(index+len(items), newLen), sliceVal.Slice(index+removeCount, sliceVal.Len()))
+
+	sliceVal.Set(newSlice)
+	return removed
+}
+
+// applySplices applies the index splices of a polymer splices change record to sliceVal
+// Only the added items are decoded, the rest of the slice is left untouched
+// The index of every splice refers to the array with the splices before it already applied, while the added items are only available from the final array
+// The splices are therefore converted to indices into the original slice and applied in reverse order, so applying one never shifts the ones still to come
+// An error is returned if the splices don't match the slice, in which case it's left untouched
+func applySplices(sliceVal reflect.Value, splices *js.Object) error {
+	indexSplices := splices.Get("indexSplices")
+	if indexSplices == nil || indexSplices == js.Undefined {
+		return fmt.Errorf("Change record has no indexSplices")
+	}
+
+	type pendingSplice struct {
+		index, removeCount int
+		items              []reflect.Value
+	}
+
+	elemType := sliceVal.Type().Elem()
+	pending := make([]pendingSplice, indexSplices.Length())
+	shift, end := 0, 0
+	for i := range pending {
+		splice := indexSplices.Index(i)
+		index := splice.Get("index").Int()
+		removeCount := splice.Get("removed").Length()
+		addedCount := splice.Get("addedCount").Int()
+
+		// Convert the index to one into the original slice, splices are ordered and don't overlap
+		origIndex := index - shift
+		if origIndex < end || origIndex+removeCount > sliceVal.Len() {
+			return fmt.Errorf("Splice at index %v removing %v items doesn't match a slice of length %v", index, removeCount, sliceVal.Len())
+		}
+
+		added := splice.Get("object").Call("slice", index, index+addedCount)
+		items := make([]reflect.Value, added.Length())
+		for j := range items {
+			items[j] = reflect.New(elemType).Elem()
+			if err := decodeRaw(added.Index(j), items[j]); err != nil {
+				return err
+			}
+		}
+
+		pending[i] = pendingSplice{origIndex, removeCount, items}
+		shift += addedCount - removeCount
+		end = origIndex + removeCount
+	}
+
+	for i := len(pending) - 1; i >= 0; i-- {
+		spliceValues(sliceVal, pending[i].index, pending[i].removeCount, pending[i].items)
+	}
+
+	return nil
+}
+
+// callArrayMethod calls one of polymer's array mutation methods for path, while marking path as notifying so the observers don't decode the array again
+// It's called before the Go slice is changed, so if polymer raises an error the Go slice is left untouched
+func (p *Proto) callArrayMethod(method, path string, args ...interface{}) {
+	// Empty slices are encoded as undefined, polymer's array methods need an actual array to work on
+	if jsArray := p.this.Call("get", path); jsArray == nil || jsArray == js.Undefined {
+		p.doNotify(path, js.Global.Get("Array").New())
+	}
+
+	p.notifying = append(p.notifying, path)
+	defer func() { p.notifying = p.notifying[:len(p.notifying)-1] }()
+
+	p.this.Call(method, append([]interface{}{path}, args...)...)
+}
+
+// clampSplice converts the index and removeCount arguments of a splice to bounds within a slice of length n, the same way javascript does
+func clampSplice(n, index, removeCount int) (int, int) {
+	if index < 0 {
+		index += n
+	}
+	if index < 0 {
+		index = 0
+	}
+	if index > n {
+		index = n
+	}
+
+	if removeCount < 0 {
+		removeCount = 0
+	}
+	if removeCount > n-index {
+		removeCount = n - index
+	}
+
+	return index, removeCount
+}
+
+// encodeItems encodes items for passing to polymer
+func encodeItems(items []interface{}) []interface{} {
+	encoded := make([]interface{}, len(items))
+	for i, item := range items {
+		if item != nil {
+			encoded[i], _ = encodeRaw(reflect.ValueOf(item))
+		}
+	}
+
+	return encoded
+}
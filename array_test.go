@@ -0,0 +1,82 @@
+/*
+Copyright 2015 Palm Stone Games, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package polymer
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestClampSplice(t *testing.T) {
+	tests := []struct {
+		n, index, removeCount int
+		wantIndex, wantRemove int
+	}{
+		{5, 1, 2, 1, 2},
+		{5, 0, 0, 0, 0},
+		{5, 5, 1, 5, 0},
+		{5, 7, 1, 5, 0},
+		{5, -1, 1, 4, 1},
+		{5, -2, 5, 3, 2},
+		{5, -7, 1, 0, 1},
+		{5, 1, -3, 1, 0},
+		{5, 3, 10, 3, 2},
+		{0, 0, 1, 0, 0},
+	}
+
+	for _, test := range tests {
+		index, removeCount := clampSplice(test.n, test.index, test.removeCount)
+		if index != test.wantIndex || removeCount != test.wantRemove {
+			t.Errorf("clampSplice(%v, %v, %v) = %v, %v, want %v, %v", test.n, test.index, test.removeCount, index, removeCount, test.wantIndex, test.wantRemove)
+		}
+	}
+}
+
+func TestSpliceValues(t *testing.T) {
+	tests := []struct {
+		slice              []string
+		index, removeCount int
+		items              []string
+		want, wantRemoved  []string
+	}{
+		{[]string{"a", "b", "c"}, 1, 1, nil, []string{"a", "c"}, []string{"b"}},
+		{[]string{"a", "b", "c"}, 1, 0, []string{"x", "y"}, []string{"a", "x", "y", "b", "c"}, []string{}},
+		{[]string{"a", "b", "c"}, 0, 3, []string{"x"}, []string{"x"}, []string{"a", "b", "c"}},
+		{[]string{"a", "b", "c"}, 3, 0, []string{"d"}, []string{"a", "b", "c", "d"}, []string{}},
+		{nil, 0, 0, []string{"a"}, []string{"a"}, []string{}},
+	}
+
+	for _, test := range tests {
+		slice := append([]string(nil), test.slice...)
+		shared := slice
+		items := make([]reflect.Value, len(test.items))
+		for i, item := range test.items {
+			items[i] = reflect.ValueOf(item)
+		}
+
+		removed := spliceValues(reflect.ValueOf(&slice).Elem(), test.index, test.removeCount, items)
+		if !reflect.DeepEqual(slice, test.want) {
+			t.Errorf("spliceValues(%q, %v, %v, %q) left %q, want %q", test.slice, test.index, test.removeCount, test.items, slice, test.want)
+		}
+		if !reflect.DeepEqual(removed.Interface(), test.wantRemoved) {
+			t.Errorf("spliceValues(%q, %v, %v, %q) removed %q, want %q", test.slice, test.index, test.removeCount, test.items, removed.Interface(), test.wantRemoved)
+		}
+		if !reflect.DeepEqual(shared, test.slice) && !(len(shared) == 0 && len(test.slice) == 0) {
+			t.Errorf("spliceValues(%q, %v, %v, %q) changed the original backing array to %q", test.slice, test.index, test.removeCount, test.items, shared)
+		}
+	}
+}
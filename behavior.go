@@ -0,0 +1,118 @@
+/*
+Copyright 2015 Palm Stone Games, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package polymer
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gopherjs/gopherjs/js"
+)
+
+const behaviorKeyPrefix = "_polymer_behavior_"
+
+// RegisterBehavior publishes the type of proto as a polymer behavior under window[name], so plain javascript elements can list it in their behaviors
+// The type is analyzed the same way Register does, so its properties, observers and handlers all become part of the elements using the behavior
+// Every element using the behavior gets its own Go instance of the type, which goes through the same lifecycle callbacks as the element
+// The returned definition can also be passed to WithBehaviors to use the behavior from Go elements
+//
+// Like Register, RegisterBehavior panics if the behavior can't be registered and logs the other issues found with it, use RegisterBehaviorE to get all of them returned as an error instead
+func RegisterBehavior(name string, proto Interface) *ElementDefinition {
+	def, err := registerBehavior(name, proto, false)
+	if err != nil {
+		panic(err.Error())
+	}
+
+	def.logFindings(name)
+	return def
+}
+
+// RegisterBehaviorE registers a behavior like RegisterBehavior does, but returns an error listing all problems with the definition instead of panicking
+// Like RegisterE, it also fails for the issues RegisterBehavior only logs, nothing is registered when an error is returned
+func RegisterBehaviorE(name string, proto Interface) (*ElementDefinition, error) {
+	return registerBehavior(name, proto, true)
+}
+
+// ValidateBehavior checks the definition of a behavior the same way RegisterBehaviorE does, without registering it
+func ValidateBehavior(name string, proto Interface) error {
+	if _, _, err := newBehaviorDefinition(name, proto, true); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// newBehaviorDefinition builds the definition of a behavior without registering it, it returns the behaviors the definition depends on as well
+// When strict is set, the findings of the stricter checks are treated as problems as well
+func newBehaviorDefinition(name string, proto Interface, strict bool) (*ElementDefinition, []interface{}, error) {
+	var problems []string
+	if name == "" || strings.ContainsAny(name, ". ") {
+		problems = append(problems, fmt.Sprintf("Invalid behavior name '%v', behavior names must be valid javascript identifiers", name))
+	}
+
+	refType, err := protoType(proto)
+	if err != nil {
+		return nil, nil, &ValidationError{Name: name, Problems: append(problems, err.Error())}
+	}
+
+	def := newDefinition(refType, behaviorKeyPrefix+name, "observe_"+name)
+	def.behaviorName = name
+	def.problems = problems
+	behaviors := buildDefinition(def, proto)
+	if problems := def.allProblems(strict); len(problems) != 0 {
+		return nil, nil, &ValidationError{Name: name, Problems: problems}
+	}
+
+	return def, behaviors, nil
+}
+
+// registerBehavior publishes the behavior, see RegisterBehavior
+func registerBehavior(name string, proto Interface, strict bool) (*ElementDefinition, error) {
+	def, behaviors, err := newBehaviorDefinition(name, proto, strict)
+	if err != nil {
+		return nil, err
+	}
+	def.behaviors = append([]interface{}(nil), behaviors...)
+
+	// The behavior object is only built once it's first accessed, as any behaviors it depends on might only be loaded by then
+	var behaviorObj *js.Object
+	js.Global.Get("Object").Call("defineProperty", js.Global, name, js.M{
+		"configurable": true,
+		"get": func() *js.Object {
+			if behaviorObj == nil {
+				if len(behaviors) != 0 {
+					def.protoDef["behaviors"] = resolveBehaviors(behaviors)
+				}
+
+				behaviorObj = InterfaceToJsObject(def.protoDef)
+			}
+
+			return behaviorObj
+		},
+	})
+
+	return def, nil
+}
+
+// jsBehavior returns the object to use when the definition is listed as a behavior
+func (def *ElementDefinition) jsBehavior() interface{} {
+	if def.behaviorName != "" {
+		return js.Global.Get(def.behaviorName)
+	}
+
+	return def.protoDef
+}
@@ -20,16 +20,22 @@ import (
 	"fmt"
 	"reflect"
 	"strings"
+	"time"
 
 	"github.com/gopherjs/gopherjs/js"
 )
 
 var (
+	typeOfError    = reflect.TypeOf((*error)(nil)).Elem()
 	typeOfPtrProto = reflect.TypeOf(&Proto{})
 	typeOfJsObject = reflect.TypeOf(&js.Object{})
+	typeOfTime     = reflect.TypeOf(time.Time{})
+	typeOfEncoder  = reflect.TypeOf((*Encoder)(nil)).Elem()
+	typeOfDecoder  = reflect.TypeOf((*Decoder)(nil)).Elem()
 )
 
-func createdCallback(refType reflect.Type) *js.Object {
+func createdCallback(def *ElementDefinition) *js.Object {
+	refType := def.refType
 	return js.MakeFunc(func(this *js.Object, arguments []*js.Object) interface{} {
 		// Create a new Go side object
 		refVal := reflect.New(refType.Elem())
@@ -38,14 +44,16 @@ func createdCallback(refType reflect.Type) *js.Object {
 
 		// Set the proto value, this is needed because we get our callers to embed *polymer.Proto, and it needs to get instantiated
 		refVal.FieldByName("Proto").Set(reflect.ValueOf(&Proto{}))
+		allocMixins(refVal)
 
 		// Store ourselves in js land so we can map js to proto
-		jsMap = append(jsMap, proto)
-		this.Set(protoIndexKey, len(jsMap)-1)
+		storeInstance(this, def.key, proto)
 
 		// Set data on the proto
 		data := proto.data()
 		data.this = this
+		data.self = proto
+		data.def = def
 		data.Element = WrapJSElement(this)
 
 		// Setup channel based event handlers
@@ -65,6 +73,32 @@ func createdCallback(refType reflect.Type) *js.Object {
 	})
 }
 
+// allocMixins allocates all nil embedded struct pointers of refVal, so the fields promoted from them can be accessed
+func allocMixins(refVal reflect.Value) {
+	refType := refVal.Type()
+	for i := 0; i < refType.NumField(); i++ {
+		field := refType.Field(i)
+		if !isMixin(field) {
+			continue
+		}
+
+		fieldVal := refVal.Field(i)
+		if fieldVal.Kind() == reflect.Ptr {
+			if fieldVal.IsNil() {
+				if !fieldVal.CanSet() {
+					continue
+				}
+
+				fieldVal.Set(reflect.New(field.Type.Elem()))
+			}
+
+			fieldVal = fieldVal.Elem()
+		}
+
+		allocMixins(fieldVal)
+	}
+}
+
 func ensureReady(proto Interface) {
 	refVal := reflect.ValueOf(proto).Elem()
 	refType := reflect.TypeOf(proto).Elem()
@@ -76,17 +110,11 @@ func ensureReady(proto Interface) {
 
 	data.ready = true
 
-	// Set initial field values
-	for i := 0; i < refType.NumField(); i++ {
+	// Set initial field values, fields of mixins are handled as if they were declared on the element itself
+	for _, fieldType := range parseFields(refType) {
 		// Get field info first
-		fieldVal := refVal.Field(i)
-		fieldType := refType.Field(i)
-		jsName := getJsName(fieldType.Name)
-
-		// Ignore the *Proto and *BindProto anonymous field
-		if fieldType.Anonymous && (fieldType.Type == typeOfPtrProto || fieldType.Type == typeOfPtrBindProto) {
-			continue
-		}
+		fieldVal := refVal.FieldByIndex(fieldType.Index)
+		jsName := getFieldJsName(fieldType)
 
 		// Special case check to not overwrite Model on dom-bind templates
 		if _, ok := proto.(*autoBindTemplate); ok && fieldType.Name == "Model" {
@@ -98,6 +126,11 @@ func ensureReady(proto Interface) {
 			continue
 		}
 
+		// Computed fields are owned by polymer, their value flows from JS to Go only
+		if parseTag(fieldType).computed != "" {
+			continue
+		}
+
 		// If the value in JS is set, we take it over
 		// Otherwise, we take over the (usually zeroed) go value and set it in JS
 		// We can get away with doing this for only first level values, as they'll either get decoded recursively if they were set
@@ -119,59 +152,169 @@ func ensureReady(proto Interface) {
 			}
 		}
 	}
+
+	// Now that Go and polymer agree on the values, take the initial snapshot for dirty checking
+	if data.def != nil && data.def.syncedFields != nil {
+		data.snapshot = data.takeSnapshot()
+	}
 }
 
-func readyCallback() *js.Object {
+func readyCallback(key string) *js.Object {
 	return js.MakeFunc(func(this *js.Object, arguments []*js.Object) interface{} {
 		// Lookup the proto
-		proto := lookupProto(this)
+		proto := lookupInstance(this, key)
 
 		ensureReady(proto)
 		proto.Ready()
+		proto.data().autoSync()
 
 		return nil
 	})
 }
 
-func attachedCallback() *js.Object {
+func attachedCallback(key string) *js.Object {
 	return js.MakeFunc(func(this *js.Object, arguments []*js.Object) interface{} {
 		// Lookup the proto
-		proto := lookupProto(this)
+		proto := lookupInstance(this, key)
 
 		// Call the proto side callback for user hooks
 		proto.Attached()
+		proto.data().autoSync()
 
 		return nil
 	})
 }
 
-func detachedCallback() *js.Object {
+func detachedCallback(key string) *js.Object {
 	return js.MakeFunc(func(this *js.Object, arguments []*js.Object) interface{} {
 		// Lookup the proto
-		proto := lookupProto(this)
+		proto := lookupInstance(this, key)
 
 		// Call the proto side callback for user hooks
 		proto.Detached()
+		proto.data().autoSync()
 
 		return nil
 	})
 }
 
-func observeShallowCallback(path []string) *js.Object {
+func observeShallowCallback(key string, path []string, observer reflect.Value) *js.Object {
 	return js.MakeFunc(func(this *js.Object, jsArgs []*js.Object) interface{} {
-		setObservedValue(lookupProto(this), path, jsArgs[0])
+		proto := lookupInstance(this, key)
+		observeValue(proto, path, observer, func() {
+			setObservedValue(proto, path, jsArgs[0])
+		})
 		return nil
 	})
 }
 
-func observeDeepCallback() *js.Object {
+func observeDeepCallback(key string, path []string, observer reflect.Value) *js.Object {
 	return js.MakeFunc(func(this *js.Object, jsArgs []*js.Object) interface{} {
+		proto := lookupInstance(this, key)
 		record := jsArgs[0]
-		setObservedValue(lookupProto(this), strings.Split(record.Get("path").String(), "."), record.Get("value"))
+		observeValue(proto, path, observer, func() {
+			setDeepObservedValue(proto, strings.Split(record.Get("path").String(), "."), record.Get("value"))
+		})
 		return nil
 	})
 }
 
+// setDeepObservedValue decodes the value of a deep observer change record into the Go value at path
+// Array mutations are reported as path.splices and path.length records, the splices are applied to the Go slice incrementally
+// If that fails, the whole slice is decoded again
+func setDeepObservedValue(proto Interface, path []string, val *js.Object) {
+	if n := len(path); n > 1 && (path[n-1] == "splices" || path[n-1] == "length") {
+		base := path[:n-1]
+		sliceVal := getRefValForPath(proto, base)
+		if sliceVal.Kind() == reflect.Slice {
+			if path[n-1] == "splices" {
+				if err := applySplices(sliceVal, val); err != nil {
+					setObservedValue(proto, base, proto.data().this.Call("get", strings.Join(base, ".")))
+				}
+			}
+			return
+		}
+	}
+
+	// Collection keys that can't be resolved, such as those of duplicate primitive items, are handled by decoding the whole array again
+	if _, err := lookupPath(proto, path); err != nil {
+		for i := len(path) - 1; i > 0; i-- {
+			if strings.HasPrefix(path[i], "#") {
+				setObservedValue(proto, path[:i], proto.data().this.Call("get", strings.Join(path[:i], ".")))
+				return
+			}
+		}
+	}
+
+	setObservedValue(proto, path, val)
+}
+
+// observeValue runs the passed update function, which is expected to change the Go value at path
+// Changes that originate from the Go side (Notify and the array mutation methods) are skipped, as the Go value is already up to date
+// If an observer method is set, it's then called with the new and old values of the field at path
+func observeValue(proto Interface, path []string, observer reflect.Value, update func()) {
+	data := proto.data()
+	if data.isNotifying(strings.Join(path, ".")) {
+		return
+	}
+
+	// polymer refuses writes to readOnly properties itself, but lets changes below them through, those are reported and not decoded
+	if data.def != nil && data.def.readOnly[path[0]] {
+		js.Global.Get("console").Call("error", fmt.Sprintf("Property '%v' of <%v> is readOnly and can only be changed from Go, ignoring the change made from javascript", path[0], data.this.Get("is")))
+		return
+	}
+
+	if !observer.IsValid() {
+		update()
+		data.refreshSnapshot(strings.Join(path, "."))
+		return
+	}
+
+	oldVal := deepCopy(getRefValForPath(proto, path))
+	update()
+	data.refreshSnapshot(strings.Join(path, "."))
+	newVal := getRefValForPath(proto, path)
+
+	args := []reflect.Value{reflect.ValueOf(proto), newVal, oldVal}
+	observer.Call(args[:observer.Type().NumIn()])
+	data.autoSync()
+}
+
+// deepCopy returns a copy of refVal that shares no slices or pointers with the original
+func deepCopy(refVal reflect.Value) reflect.Value {
+	copyVal := reflect.New(refVal.Type()).Elem()
+
+	switch refVal.Kind() {
+	case reflect.Ptr:
+		if refVal.IsNil() || refVal.Type() == typeOfJsObject {
+			copyVal.Set(refVal)
+		} else {
+			copyVal.Set(reflect.New(refVal.Type().Elem()))
+			copyVal.Elem().Set(deepCopy(refVal.Elem()))
+		}
+	case reflect.Slice:
+		if refVal.IsNil() {
+			break
+		}
+
+		copyVal.Set(reflect.MakeSlice(refVal.Type(), refVal.Len(), refVal.Len()))
+		for i := 0; i < refVal.Len(); i++ {
+			copyVal.Index(i).Set(deepCopy(refVal.Index(i)))
+		}
+	case reflect.Struct:
+		copyVal.Set(refVal)
+		for i := 0; i < refVal.NumField(); i++ {
+			if isFieldExported(refVal.Type().Field(i).Name) {
+				copyVal.Field(i).Set(deepCopy(refVal.Field(i)))
+			}
+		}
+	default:
+		copyVal.Set(refVal)
+	}
+
+	return copyVal
+}
+
 // reflectArgs builds up reflect args
 // We loop through the function arguments and use the types of each argument to decode the jsArgs
 // If the function has more arguments than we have jsArgs, they're passed in as Zero values
@@ -202,9 +345,9 @@ func reflectArgs(handler reflect.Value, proto interface{}, jsArgs []*js.Object)
 	return reflectArgs, nil
 }
 
-func eventHandlerCallback(handler reflect.Value) *js.Object {
+func eventHandlerCallback(key string, handler reflect.Value) *js.Object {
 	return js.MakeFunc(func(this *js.Object, jsArgs []*js.Object) interface{} {
-		proto := lookupProto(this)
+		proto := lookupInstance(this, key)
 
 		jsArgs[0] = js.Global.Get("Polymer").Call("dom", jsArgs[0])
 
@@ -223,6 +366,7 @@ func eventHandlerCallback(handler reflect.Value) *js.Object {
 		}
 
 		handler.Call(args)
+		proto.data().autoSync()
 		return nil
 	})
 }
@@ -243,9 +387,9 @@ func eventChanCallback(handlerChan reflect.Value) *js.Object {
 	})
 }
 
-func computeCallback(handler reflect.Value) *js.Object {
+func computeCallback(key string, handler reflect.Value) *js.Object {
 	return js.MakeFunc(func(this *js.Object, jsArgs []*js.Object) interface{} {
-		proto := lookupProto(this)
+		proto := lookupInstance(this, key)
 		ensureReady(proto)
 
 		var (
@@ -267,7 +411,94 @@ func computeCallback(handler reflect.Value) *js.Object {
 		}
 
 		returnArgs = handler.Call(args)
+		proto.data().autoSync()
 		encodedReturn, _ := encodeRaw(returnArgs[0])
 		return encodedReturn
 	})
 }
+
+func observerMethodCallback(key string, handler reflect.Value) *js.Object {
+	return js.MakeFunc(func(this *js.Object, jsArgs []*js.Object) interface{} {
+		proto := lookupInstance(this, key)
+		ensureReady(proto)
+
+		args, err := reflectArgs(handler, proto, jsArgs)
+		if err != nil {
+			Log("Suppressed observer function %v due to error while decoding: %v", handler, err)
+			return nil
+		}
+
+		handler.Call(args)
+		proto.data().autoSync()
+		return nil
+	})
+}
+
+func exportCallback(key string, handler reflect.Value) *js.Object {
+	handlerType := handler.Type()
+	returnsError := handlerType.NumOut() != 0 && handlerType.Out(handlerType.NumOut()-1) == typeOfError
+
+	return js.MakeFunc(func(this *js.Object, jsArgs []*js.Object) interface{} {
+		proto := lookupInstance(this, key)
+		ensureReady(proto)
+
+		args, err := reflectArgs(handler, proto, jsArgs)
+		if !returnsError {
+			if err != nil {
+				panic(fmt.Sprintf("Error while decoding arguments for %v: %v", handler, err))
+			}
+
+			returnArgs := handler.Call(args)
+			proto.data().autoSync()
+			if len(returnArgs) == 0 {
+				return nil
+			}
+
+			encodedReturn, _ := encodeRaw(returnArgs[0])
+			return encodedReturn
+		}
+
+		// Methods returning an error may block, so run them in a goroutine and hand back a promise
+		// The method itself runs outside of the serialization guaranteed for callbacks, its completion is queued through Do
+		return js.Global.Get("Promise").New(func(resolve, reject *js.Object) {
+			if err != nil {
+				reject.Invoke(js.Global.Get("Error").New(err.Error()))
+				return
+			}
+
+			go func() {
+				var returnArgs []reflect.Value
+				func() {
+					defer func() {
+						if r := recover(); r != nil {
+							err = fmt.Errorf("%v panicked: %v", handler, r)
+						}
+					}()
+
+					returnArgs = handler.Call(args)
+				}()
+
+				Do(func() {
+					if err != nil {
+						reject.Invoke(js.Global.Get("Error").New(err.Error()))
+						return
+					}
+
+					proto.data().autoSync()
+					if errVal := returnArgs[len(returnArgs)-1]; !errVal.IsNil() {
+						reject.Invoke(js.Global.Get("Error").New(errVal.Interface().(error).Error()))
+						return
+					}
+
+					if len(returnArgs) == 1 {
+						resolve.Invoke()
+						return
+					}
+
+					encodedReturn, _ := encodeRaw(returnArgs[0])
+					resolve.Invoke(encodedReturn)
+				})
+			}()
+		})
+	})
+}
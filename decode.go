@@ -156,7 +156,7 @@ func decodeStruct(jsVal *js.Object, refVal reflect.Value) error {
 
 		tag := fieldType.Tag.Get("polymer-decode")
 		if tag == "" {
-			tag = getJsName(fieldType.Name)
+			tag = getFieldJsName(fieldType)
 		}
 
 		// If the value is called underlying and is a *js.Object, set the underlying js object on it
@@ -198,8 +198,18 @@ func decodeStruct(jsVal *js.Object, refVal reflect.Value) error {
 }
 
 func getRefValForPath(proto Interface, path []string) reflect.Value {
+	refVal, err := lookupPath(proto, path)
+	if err != nil {
+		panic(err.Error())
+	}
+
+	return refVal
+}
+
+// lookupPath returns the Go value at the given polymer path of proto
+// Path segments can be field names, slice indices or polymer collection keys (#3)
+func lookupPath(proto Interface, path []string) (reflect.Value, error) {
 	refVal := reflect.ValueOf(proto).Elem()
-	prevVal := refVal
 
 	for i, curr := range path {
 		if refVal.Kind() == reflect.Interface {
@@ -210,34 +220,219 @@ func getRefValForPath(proto Interface, path []string) reflect.Value {
 			refVal = refVal.Elem()
 		}
 
-		if curr[0] == '#' {
-			index, err := strconv.ParseInt(curr[1:], 10, 32)
-			if err != nil {
-				panic(err)
+		if !refVal.IsValid() {
+			return reflect.Value{}, fmt.Errorf("Path '%s' is invalid\nParent is nil, so couldn't navigate further.", strings.Join(path[:i+1], "."))
+		}
+
+		switch {
+		case refVal.Kind() == reflect.Slice:
+			var index int
+			var err error
+			if strings.HasPrefix(curr, "#") {
+				index, err = collectionIndex(proto, path[:i], curr)
+			} else {
+				index, err = strconv.Atoi(curr)
+			}
+			if err != nil || index < 0 || index >= refVal.Len() {
+				return reflect.Value{}, fmt.Errorf("Path '%s' is invalid\nExpected an index between 0 and %d, but got '%s'", strings.Join(path[:i+1], "."), refVal.Len()-1, curr)
 			}
 
-			refVal = refVal.Index(int(index))
-		} else {
-			if refVal.Kind() != reflect.Struct {
-				panic(fmt.Sprintf("Path '%s' is invalid\nExpected parent to be a struct, but got a %s, so couldn't navigate further.", strings.Join(path[:i+1], "."), refVal.Kind()))
+			refVal = refVal.Index(index)
+		case refVal.Kind() == reflect.Struct:
+			parentVal := refVal
+			refVal = fieldByJsName(refVal, curr)
+			if !refVal.IsValid() {
+				refType := parentVal.Type()
+				var fieldNames []string
+				for i := 0; i < refType.NumField(); i++ {
+					fieldNames = append(fieldNames, getFieldJsName(refType.Field(i)))
+				}
+				return reflect.Value{}, fmt.Errorf("Path '%s' is invalid\nList of valid field names on this level: %s", strings.Join(path[:i+1], "."), strings.Join(fieldNames, ", "))
 			}
+		default:
+			return reflect.Value{}, fmt.Errorf("Path '%s' is invalid\nExpected parent to be a struct or slice, but got a %s, so couldn't navigate further.", strings.Join(path[:i+1], "."), refVal.Kind())
+		}
+	}
+
+	return refVal, nil
+}
+
+// pathForPointer returns the polymer path of the value ptr points to, which should be a field of rootVal or a value nested in one
+// Fields are found inside nested structs, through pointers and inside slices, their paths follow the same naming as getFieldJsName
+// When boundOnly is set, only the fields of rootVal that are exposed to polymer through the bind or computed tag options are searched
+// Pointers are compared through reflect only, pointers to anything that isn't a field, a nested field or a slice element are rejected with an error
+func pathForPointer(rootVal reflect.Value, ptr interface{}, boundOnly bool) (string, error) {
+	ptrVal := reflect.ValueOf(ptr)
+	if ptrVal.Kind() != reflect.Ptr || ptrVal.IsNil() {
+		return "", fmt.Errorf("Expected a pointer to a field, got %T", ptr)
+	}
+
+	if path, ok := searchPointerInStruct(rootVal, ptrVal, nil, boundOnly, make(map[uintptr]bool)); ok {
+		return strings.Join(path, "."), nil
+	}
+
+	if boundOnly {
+		return "", fmt.Errorf("%T does not point to a bound field of %v, or a value nested in one", ptr, rootVal.Type())
+	}
 
-			refVal = refVal.FieldByNameFunc(func(s string) bool { return getJsName(s) == curr })
+	return "", fmt.Errorf("%T does not point to a field of %v, or a value nested in one", ptr, rootVal.Type())
+}
+
+// pathsForPointers returns the polymer paths of all pointers in ptrs, see pathForPointer
+func pathsForPointers(rootVal reflect.Value, ptrs []interface{}, boundOnly bool) ([]string, error) {
+	paths := make([]string, 0, len(ptrs))
+	for _, ptr := range ptrs {
+		path, err := pathForPointer(rootVal, ptr, boundOnly)
+		if err != nil {
+			return nil, err
 		}
 
-		if !refVal.IsValid() {
-			refType := prevVal.Type()
-			var fieldNames []string
-			for i := 0; i < refType.NumField(); i++ {
-				fieldNames = append(fieldNames, getJsName(refType.Field(i).Name))
+		paths = append(paths, path)
+	}
+
+	return paths, nil
+}
+
+// searchPointerInStruct looks for the field of structVal that ptrVal points to, recursing into the values of the fields
+func searchPointerInStruct(structVal reflect.Value, ptrVal reflect.Value, path []string, boundOnly bool, seen map[uintptr]bool) ([]string, bool) {
+	for _, field := range parseFields(structVal.Type()) {
+		if !isFieldExported(field.Name) || field.Type.Kind() == reflect.Chan || (boundOnly && !parseTag(field).bound()) {
+			continue
+		}
+
+		// Mixin fields are flattened, so look up the struct that actually holds the field
+		parentVal := structVal
+		for _, i := range field.Index[:len(field.Index)-1] {
+			if parentVal = reflect.Indirect(parentVal.Field(i)); !parentVal.IsValid() {
+				break
 			}
-			panic(fmt.Sprintf("Path '%s' is invalid\nList of valid field names on this level: %s", strings.Join(path[:i+1], "."), strings.Join(fieldNames, ", ")))
+		}
+		if !parentVal.IsValid() {
+			continue
+		}
+
+		fieldPath := append(append([]string(nil), path...), getFieldJsName(field))
+		fieldVal := parentVal.Field(field.Index[len(field.Index)-1])
+		if pointsTo(fieldVal, ptrVal) {
+			return fieldPath, true
 		}
 
-		prevVal = refVal
+		if found, ok := searchPointerInValue(fieldVal, ptrVal, fieldPath, seen); ok {
+			return found, true
+		}
 	}
 
-	return refVal
+	return nil, false
+}
+
+// searchPointerInValue looks for ptrVal inside refVal, which is the value at path
+// seen holds the pointers that were already followed, so cyclic structures don't make the search loop forever
+func searchPointerInValue(refVal reflect.Value, ptrVal reflect.Value, path []string, seen map[uintptr]bool) ([]string, bool) {
+	switch refVal.Kind() {
+	case reflect.Ptr:
+		if refVal.IsNil() || refVal.Type() == typeOfJsObject || seen[refVal.Pointer()] {
+			return nil, false
+		}
+		seen[refVal.Pointer()] = true
+
+		// A pointer to a struct that's itself the value at path, e.g. NotifyField(p.Foo) where Foo is a *Bar
+		if refVal.Type() == ptrVal.Type() && refVal.Pointer() == ptrVal.Pointer() {
+			return path, true
+		}
+
+		return searchPointerInValue(refVal.Elem(), ptrVal, path, seen)
+	case reflect.Struct:
+		if refVal.Type() == typeOfTime {
+			return nil, false
+		}
+
+		return searchPointerInStruct(refVal, ptrVal, path, false, seen)
+	case reflect.Slice:
+		for i := 0; i < refVal.Len(); i++ {
+			elemPath := append(append([]string(nil), path...), strconv.Itoa(i))
+			elemVal := refVal.Index(i)
+			if pointsTo(elemVal, ptrVal) {
+				return elemPath, true
+			}
+
+			if found, ok := searchPointerInValue(elemVal, ptrVal, elemPath, seen); ok {
+				return found, true
+			}
+		}
+	}
+
+	return nil, false
+}
+
+// pointsTo returns true if ptrVal points to refVal, which should be addressable
+func pointsTo(refVal reflect.Value, ptrVal reflect.Value) bool {
+	return refVal.Type() == ptrVal.Type().Elem() && refVal.CanAddr() && refVal.Addr().Pointer() == ptrVal.Pointer()
+}
+
+// collectionIndex resolves a polymer collection key (such as #3) of the array at basePath to the index of the item it refers to
+// Keys are looked up through Polymer.Collection, as the key of an item no longer matches its index once the array has been mutated
+// An error is returned if the key is unknown, or refers to a primitive value that occurs more than once, as such values can't be told apart
+func collectionIndex(proto Interface, basePath []string, key string) (int, error) {
+	collection := js.Global.Get("Polymer").Get("Collection")
+	if collection == js.Undefined || proto.data().this == nil {
+		return 0, fmt.Errorf("Collection key %v can't be resolved without Polymer.Collection", key)
+	}
+
+	arrayPath := strings.Join(basePath, ".")
+	array := proto.data().this.Call("get", arrayPath)
+	if array == nil || array == js.Undefined {
+		return 0, fmt.Errorf("Collection key %v can't be resolved, %v is not an array", key, arrayPath)
+	}
+
+	item := collection.Call("get", array).Call("getItem", key)
+	index := -1
+	if item != js.Undefined {
+		index = array.Call("indexOf", item).Int()
+	}
+	if index == -1 {
+		return 0, fmt.Errorf("Collection key %v is unknown for %v", key, arrayPath)
+	}
+
+	if js.Global.Call("Object", item) != item && array.Call("lastIndexOf", item).Int() != index {
+		return 0, fmt.Errorf("Collection key %v of %v refers to the value %v, which occurs more than once", key, arrayPath, item)
+	}
+
+	return index, nil
+}
+
+// fieldByJsName returns the field of the struct refVal with the given javascript name
+// Fields of embedded structs are promoted the same way they are in Go, fields at a shallower depth take precedence
+// The zero Value is returned if no field was found
+func fieldByJsName(refVal reflect.Value, name string) reflect.Value {
+	refType := refVal.Type()
+	for i := 0; i < refType.NumField(); i++ {
+		if getFieldJsName(refType.Field(i)) == name {
+			return refVal.Field(i)
+		}
+	}
+
+	for i := 0; i < refType.NumField(); i++ {
+		if !isMixin(refType.Field(i)) {
+			continue
+		}
+
+		fieldVal := refVal.Field(i)
+		if fieldVal.Kind() == reflect.Ptr {
+			if fieldVal.IsNil() {
+				continue
+			}
+
+			fieldVal = fieldVal.Elem()
+		}
+
+		if fieldVal.Kind() == reflect.Struct {
+			if found := fieldByJsName(fieldVal, name); found.IsValid() {
+				return found
+			}
+		}
+	}
+
+	return reflect.Value{}
 }
 
 func setObservedValue(proto Interface, path []string, val *js.Object) {
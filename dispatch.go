@@ -0,0 +1,59 @@
+/*
+Copyright 2015 Palm Stone Games, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package polymer
+
+var (
+	dispatchQueue     []func()
+	dispatchScheduled bool
+)
+
+// Do queues f to be run on the javascript event loop, as a microtask
+// Queued functions run in the order they were queued, and never interleave with each other or with library callbacks
+// This makes Do the safe way to update bound fields from goroutines, see the package documentation for details
+// f is run from a javascript callback, so it must not block
+func Do(f func()) {
+	dispatchQueue = append(dispatchQueue, f)
+	if !dispatchScheduled {
+		dispatchScheduled = true
+		Async(-1, runDispatchQueue)
+	}
+}
+
+// Dispatch queues f like Do does, running it inside Update so all paths it notifies are flushed together
+func (p *Proto) Dispatch(f func()) {
+	Do(func() {
+		p.Update(f)
+	})
+}
+
+// runDispatchQueue runs all queued functions, including the ones queued while running
+func runDispatchQueue() {
+	defer func() {
+		// If a queued function panicked, the remaining ones still get their turn in a new microtask
+		dispatchScheduled = false
+		if len(dispatchQueue) != 0 {
+			dispatchScheduled = true
+			Async(-1, runDispatchQueue)
+		}
+	}()
+
+	for len(dispatchQueue) != 0 {
+		f := dispatchQueue[0]
+		dispatchQueue = dispatchQueue[1:]
+		f()
+	}
+}
@@ -25,6 +25,19 @@ func (p *BindProto) Notify(paths ...string) {
 	}
 }
 
+// NotifyField notifies polymer that the values the passed pointers point to have changed, see Proto.NotifyField
+// All exported fields of the model are searched, as the whole model is bound to the template
+func (p *BindProto) NotifyField(fields ...interface{}) error {
+	model := reflect.ValueOf(lookupProto(p.data().this).(*autoBindTemplate).Model).Elem()
+	paths, err := pathsForPointers(model, fields, false)
+	if err != nil {
+		return err
+	}
+
+	p.Notify(paths...)
+	return nil
+}
+
 type AutoBindGoTemplate struct {
 	*WrappedElement
 }
@@ -45,6 +58,7 @@ func (el *AutoBindGoTemplate) Bind(model BindInterface) {
 
 	// Set the BindProto
 	refVal.FieldByIndex(bindProtoField.Index).Set(reflect.New(typeOfPtrBindProto.Elem()))
+	allocMixins(refVal)
 
 	jsObj := unwrap(el.Underlying())
 	proto := lookupProto(jsObj).(*autoBindTemplate)
@@ -61,12 +75,12 @@ func (el *AutoBindGoTemplate) Bind(model BindInterface) {
 
 	// Setup handlers
 	for _, handler := range parseHandlers(refType) {
-		jsObj.Set(getJsName(handler.Name), eventHandlerCallback(handler.Func))
+		jsObj.Set(getJsName(handler.Name), eventHandlerCallback(protoKey, handler.Func))
 	}
 
 	// Setup compute functions
 	for _, handler := range parseComputes(refType) {
-		jsObj.Set(getJsName(handler.Name), computeCallback(handler.Func))
+		jsObj.Set(getJsName(handler.Name), computeCallback(protoKey, handler.Func))
 	}
 
 	// Setup channel based event handlers
@@ -102,6 +116,10 @@ type autoBindTemplate struct {
 	Model interface{} `polymer:"bind"`
 }
 
+func (t *autoBindTemplate) Extends() string {
+	return "template"
+}
+
 func (t *autoBindTemplate) Created() {
 	js.Global.Get("Polymer").Get("RenderStatus").Call("whenReady", t.markImportsReady)
 }
@@ -176,8 +194,7 @@ func (t *autoBindTemplate) render() {
 }
 
 func init() {
-	Register("dom-bind-go", &autoBindTemplate{},
-		WithExtends("template"),
+	_, err := registerElement(internalDefinitions, "dom-bind-go", &autoBindTemplate{}, []CustomRegistrationAttr{
 		CustomRegistrationAttr{"_template", nil},
 		CustomRegistrationAttr{"_registerFeatures", js.MakeFunc(func(this *js.Object, args []*js.Object) interface{} {
 			this.Call("_prepConstructor")
@@ -195,5 +212,8 @@ func init() {
 				return selector
 			}
 		})},
-	)
+	}, false)
+	if err != nil {
+		panic(err.Error())
+	}
 }
@@ -340,7 +340,7 @@ func (el *WrappedElement) SubscribeEvent(event string, callback interface{}) *Ev
 	sub := &EventSubscription{event: event}
 	switch refVal.Kind() {
 	case reflect.Func:
-		sub.funcObj = eventHandlerCallback(refVal)
+		sub.funcObj = eventHandlerCallback(protoKey, refVal)
 	case reflect.Chan:
 		sub.funcObj = eventChanCallback(refVal)
 		sub.chanRefVal = refVal
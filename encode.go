@@ -111,7 +111,7 @@ func encodeStruct(refVal reflect.Value, m js.M) bool {
 			filled = true
 		}
 
-		m[getJsName(fieldType.Name)] = jsObj
+		m[getFieldJsName(fieldType)] = jsObj
 	}
 
 	return filled
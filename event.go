@@ -17,6 +17,9 @@ limitations under the License.
 package polymer
 
 import (
+	"fmt"
+	"reflect"
+	"strings"
 	"time"
 
 	"github.com/gopherjs/gopherjs/js"
@@ -37,8 +40,18 @@ type Event struct {
 	LocalTarget Element   `polymer-decode:"localTarget"`
 	RootTarget  Element   `polymer-decode:"rootTarget"`
 	Path        []Element `polymer-decode:"path"`
+
+	// Detail is the detail of custom events, typed access is available by embedding Event in a struct declared through DeclareEvent
+	Detail *js.Object `polymer-decode:"event.detail"`
 }
 
+var (
+	typeOfEvent = reflect.TypeOf(Event{})
+
+	// eventNames maps the types passed to DeclareEvent to their event names
+	eventNames = make(map[reflect.Type]string)
+)
+
 type PropertyChangedEvent struct {
 	Event
 	JSValue *js.Object `polymer-decode:"event.detail.value"`
@@ -58,6 +71,46 @@ type MouseEvent struct {
 	ToElement   Element `polymer-decode:"event.toElement"`
 }
 
+// KeyboardEvent is the event passed to handlers of key bindings, as well as to handlers of regular keyboard events such as keydown
+type KeyboardEvent struct {
+	Event
+
+	// Combo is the key combination that triggered the handler, it's only set for handlers called through key bindings
+	Combo string
+
+	Key    string
+	Code   string
+	Repeat bool
+
+	AltKey   bool
+	CtrlKey  bool
+	MetaKey  bool
+	ShiftKey bool
+}
+
+// Decode implements the Decoder interface
+// IronA11yKeysBehavior wraps the original keyboard event in a custom event, in that case the key information is taken from the wrapped event
+func (e *KeyboardEvent) Decode(jsVal *js.Object) error {
+	if err := decodeStruct(jsVal, reflect.ValueOf(&e.Event).Elem()); err != nil {
+		return err
+	}
+
+	keyEvent := jsVal.Get("event")
+	if detail := keyEvent.Get("detail"); detail != nil && detail != js.Undefined && detail.Get("keyboardEvent") != js.Undefined {
+		e.Combo = detail.Get("combo").String()
+		keyEvent = detail.Get("keyboardEvent")
+	}
+
+	e.Key = keyEvent.Get("key").String()
+	e.Code = keyEvent.Get("code").String()
+	e.Repeat = keyEvent.Get("repeat").Bool()
+	e.AltKey = keyEvent.Get("altKey").Bool()
+	e.CtrlKey = keyEvent.Get("ctrlKey").Bool()
+	e.MetaKey = keyEvent.Get("metaKey").Bool()
+	e.ShiftKey = keyEvent.Get("shiftKey").Bool()
+	return nil
+}
+
 func (e *Event) StopPropagation() {
 	e.CancelBubble = true
 	e.Underlying.Get("event").Call("stopPropagation")
@@ -67,3 +120,99 @@ func (e *Event) PreventDefault() {
 	e.DefaultPrevented = true
 	e.Underlying.Get("event").Call("preventDefault")
 }
+
+// DeclareEvent declares evt as the Go type of the custom event with the given name, so it can be fired with FireEvent
+// evt should be a pointer to a struct embedding polymer.Event, the fields of the event detail are tagged with their path in it:
+//
+//	type SaveEvent struct {
+//		polymer.Event
+//		Message string `polymer-decode:"event.detail.message"`
+//	}
+//
+// A field tagged with `polymer-decode:"event.detail"` receives the whole detail.
+// Handlers receive the detail decoded into the same struct, by accepting a *SaveEvent argument or listening on a chan *SaveEvent
+func DeclareEvent(name string, evt interface{}) {
+	refType := reflect.TypeOf(evt)
+	if refType == nil || refType.Kind() != reflect.Ptr || refType.Elem().Kind() != reflect.Struct {
+		panic(fmt.Sprintf("Expected event %v to be a pointer to a struct, got %T", name, evt))
+	}
+	if field, ok := refType.Elem().FieldByName("Event"); !ok || !field.Anonymous || field.Type != typeOfEvent {
+		panic(fmt.Sprintf("Event type %v for %v should embed polymer.Event", refType, name))
+	}
+	if existing, ok := eventNames[refType]; ok {
+		panic(fmt.Sprintf("Event type %v has already been declared for %v", refType, existing))
+	}
+
+	eventNames[refType] = name
+}
+
+// FireEvent fires the custom event evt, which should be of a type passed to DeclareEvent
+// The detail of the event is encoded from the event detail fields of evt, after firing the embedded Event is filled in with the fired event
+func (p *Proto) FireEvent(evt interface{}) {
+	name, ok := eventNames[reflect.TypeOf(evt)]
+	if !ok {
+		panic(fmt.Sprintf("Event type %T has not been declared through DeclareEvent", evt))
+	}
+
+	jsEvent := p.this.Call("fire", name, encodeEventDetail(reflect.ValueOf(evt).Elem()))
+	if err := Decode(js.Global.Get("Polymer").Call("dom", jsEvent), evt); err != nil {
+		panic(fmt.Sprintf("Error while decoding event %v: %v", name, err))
+	}
+}
+
+// encodeEventDetail builds the detail of a custom event out of the fields of refVal tagged with a path inside event.detail
+func encodeEventDetail(refVal reflect.Value) *js.Object {
+	detail := js.Global.Get("Object").New()
+	setEventDetailFields(detail, refVal)
+
+	return detail
+}
+
+func setEventDetailFields(detail *js.Object, refVal reflect.Value) {
+	refType := refVal.Type()
+	for i := 0; i < refType.NumField(); i++ {
+		field := refType.Field(i)
+		fieldVal := refVal.Field(i)
+		if field.Type == typeOfEvent {
+			continue
+		}
+
+		// Embedded structs are decoded as if their fields were declared at this level, so encode them the same way
+		if field.Anonymous && isMixin(field) {
+			if fieldVal.Kind() == reflect.Ptr {
+				if fieldVal.IsNil() {
+					continue
+				}
+				fieldVal = fieldVal.Elem()
+			}
+
+			setEventDetailFields(detail, fieldVal)
+			continue
+		}
+
+		tag := field.Tag.Get("polymer-decode")
+		if tag != "event.detail" && !strings.HasPrefix(tag, "event.detail.") {
+			continue
+		}
+
+		jsObj, _ := encodeRaw(fieldVal)
+		if tag == "event.detail" {
+			// The whole detail, merge its properties so fields with a nested path can still add to it
+			if jsObj != nil && jsObj != js.Undefined {
+				js.Global.Get("Object").Call("assign", detail, jsObj)
+			}
+			continue
+		}
+
+		// Create the intermediate objects for nested paths
+		path := strings.Split(strings.TrimPrefix(tag, "event.detail."), ".")
+		curr := detail
+		for _, component := range path[:len(path)-1] {
+			if next := curr.Get(component); next == js.Undefined || next == nil {
+				curr.Set(component, js.Global.Get("Object").New())
+			}
+			curr = curr.Get(component)
+		}
+		curr.Set(path[len(path)-1], jsObj)
+	}
+}
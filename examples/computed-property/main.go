@@ -14,6 +14,9 @@ type Timer struct {
 	*polymer.Proto
 
 	Time time.Time `polymer:"bind"`
+
+	// TimeString is a real polymer property, recomputed through ComputeTime whenever time changes
+	TimeString string `polymer:"computed=ComputeTime(time)"`
 }
 
 func (t *Timer) Created() {
@@ -7,14 +7,21 @@ import (
 )
 
 func init() {
+	polymer.DeclareEvent("custom-event", &CustomEvent{})
 	polymer.Register("parent-container", &ParentContainer{})
 	polymer.Register("data-container", &DataContainer{})
 }
 
+// CustomEvent is fired by data-container and handled by parent-container, the message is sent along in the event detail
+type CustomEvent struct {
+	polymer.Event
+	Message string `polymer-decode:"event.detail.message"`
+}
+
 type ParentContainer struct {
 	*polymer.Proto
 
-	DoCustomEvent chan *polymer.Event `polymer:"handler"`
+	DoCustomEvent chan *CustomEvent `polymer:"handler"`
 
 	WasFired string `polymer:"bind"`
 }
@@ -35,7 +42,7 @@ func (p *ParentContainer) listenToggleEvents() {
 			case e := <-p.DoCustomEvent:
 				p.WasFired = "Yes"
 				p.Notify("wasFired")
-				fmt.Printf("%v\n", e.Underlying.Get("event").Get("detail").Get("message").String())
+				fmt.Printf("%v\n", e.Message)
 			}
 
 		}
@@ -59,9 +66,7 @@ func (d *DataContainer) Ready() {
 func (d *DataContainer) HandleInput() {
 	polymer.Async(1, func() {
 		if d.FirePassword == "Fire" {
-			d.Fire("custom-event", map[string]interface{}{
-				"message": "Event Fired from Child",
-			})
+			d.FireEvent(&CustomEvent{Message: "Event Fired from Child"})
 		}
 	})
 }
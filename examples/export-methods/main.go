@@ -0,0 +1,50 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/PalmStoneGames/polymer"
+)
+
+func init() {
+	polymer.Register("message-box", &MessageBox{})
+}
+
+type MessageBox struct {
+	*polymer.Proto
+
+	Opened  bool   `polymer:"bind,reflectToAttribute"`
+	Message string `polymer:"bind"`
+}
+
+// Exports makes Open, Close and Load callable from javascript as open(), close() and load()
+func (m *MessageBox) Exports() []string {
+	return []string{"Open", "Close", "Load"}
+}
+
+func (m *MessageBox) Open(message string) {
+	m.Message = message
+	m.Opened = true
+	m.Notify("message", "opened")
+}
+
+func (m *MessageBox) Close() bool {
+	wasOpened := m.Opened
+	m.Opened = false
+	m.Notify("opened")
+	return wasOpened
+}
+
+// Load blocks, since it returns an error it is run in a goroutine and returns a Promise to javascript
+func (m *MessageBox) Load(id int) (string, error) {
+	if id < 0 {
+		return "", errors.New("id must be positive")
+	}
+
+	time.Sleep(time.Second)
+	return fmt.Sprintf("Message #%v, loaded from Go", id), nil
+}
+
+func main() {}
@@ -0,0 +1,23 @@
+package main
+
+import (
+	"github.com/PalmStoneGames/polymer"
+)
+
+func init() {
+	polymer.RegisterBehavior("CounterBehavior", &Counter{})
+}
+
+// Counter is published as a behavior, every element listing CounterBehavior gets its own Counter instance
+type Counter struct {
+	*polymer.Proto
+
+	Count int `polymer:"bind"`
+}
+
+func (c *Counter) HandleIncrement() {
+	c.Count++
+	c.Notify("count")
+}
+
+func main() {}
@@ -0,0 +1,47 @@
+package main
+
+import (
+	"github.com/PalmStoneGames/polymer"
+)
+
+func init() {
+	polymer.Register("toggle-button", &ToggleButton{})
+}
+
+type ToggleButton struct {
+	*polymer.Proto
+
+	Pressed bool `polymer:"bind,reflectToAttribute"`
+
+	Tap chan *polymer.Event `polymer:"handler,listen=tap"`
+}
+
+// Listeners declares the host listeners that are handled by Handle methods
+func (t *ToggleButton) Listeners() map[string]string {
+	return map[string]string{
+		"focus": "HandleFocus",
+	}
+}
+
+// HostAttributes makes the host element focusable and exposes its role to assistive technology
+func (t *ToggleButton) HostAttributes() map[string]interface{} {
+	return map[string]interface{}{
+		"role":     "button",
+		"tabindex": 0,
+	}
+}
+
+func (t *ToggleButton) Ready() {
+	go func() {
+		for range t.Tap {
+			t.Pressed = !t.Pressed
+			t.Notify("pressed")
+		}
+	}()
+}
+
+func (t *ToggleButton) HandleFocus(e *polymer.Event) {
+	polymer.Log("toggle-button focused: ", e)
+}
+
+func main() {}
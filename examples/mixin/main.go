@@ -0,0 +1,56 @@
+package main
+
+import (
+	"strconv"
+
+	"github.com/PalmStoneGames/polymer"
+)
+
+func init() {
+	polymer.Register("fruit-list", &FruitList{})
+}
+
+// Selection is a mixin that can be embedded in any element showing a list of selectable items
+// Its fields and methods are promoted into the embedding element, which can build on them
+type Selection struct {
+	Selected int `polymer:"bind,observer=OnSelectedChanged"`
+}
+
+func (s *Selection) HandleSelect(e *polymer.Event) {
+	index, err := strconv.Atoi(e.LocalTarget.GetAttribute("data-index"))
+	if err != nil {
+		return
+	}
+
+	s.Selected = index
+}
+
+type FruitList struct {
+	*polymer.Proto
+	Selection
+
+	Fruits []string `polymer:"bind"`
+}
+
+func (f *FruitList) Created() {
+	f.Fruits = []string{"Apple", "Banana", "Cherry"}
+}
+
+func (f *FruitList) OnSelectedChanged(newVal, oldVal int) {
+	polymer.Log("Selection changed from ", oldVal, " to ", newVal)
+}
+
+func (f *FruitList) HandleSelect(e *polymer.Event) {
+	f.Selection.HandleSelect(e)
+	f.Notify("selected")
+}
+
+func (f *FruitList) ComputeSelectedFruit(fruits []string, selected int) string {
+	if selected < 0 || selected >= len(fruits) {
+		return ""
+	}
+
+	return fruits[selected]
+}
+
+func main() {}
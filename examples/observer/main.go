@@ -0,0 +1,36 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/PalmStoneGames/polymer"
+)
+
+func init() {
+	polymer.Register("name-input", &NameInput{})
+}
+
+type NameInput struct {
+	*polymer.Proto
+
+	Name    string   `polymer:"bind,observer=OnNameChanged"`
+	Profile Profile  `polymer:"bind,observer=OnProfileChanged"`
+	History []string `polymer:"bind"`
+}
+
+type Profile struct {
+	City    string
+	Country string
+}
+
+func (n *NameInput) OnNameChanged(newName, oldName string) {
+	n.History = append(n.History, fmt.Sprintf("name: %q -> %q", oldName, newName))
+	n.Notify("history")
+}
+
+func (n *NameInput) OnProfileChanged(newProfile, oldProfile Profile) {
+	n.History = append(n.History, fmt.Sprintf("profile: %+v -> %+v", oldProfile, newProfile))
+	n.Notify("history")
+}
+
+func main() {}
@@ -33,25 +33,30 @@ func main() {
 	polymer.GetDocument().GetElementByID("tmpl").(*polymer.AutoBindGoTemplate).Bind(data)
 
 	// Start a goroutine to dynamically update the timer every second and notify
+	// The updates are queued with polymer.Do, so they can't interleave with polymer writing to the same fields
 	go func() {
-		for data.RemainingTime > 0 {
+		for i := data.RemainingTime; i > 0; i-- {
 			time.Sleep(time.Second)
-			data.RemainingTime--
-			data.Notify("remainingTime")
+			polymer.Do(func() {
+				data.RemainingTime--
+				data.NotifyField(&data.RemainingTime)
+			})
 		}
 
-		data.Text = "This text is ALSO set from Go, in a goroutine, after 5 seconds"
-		data.Notify("text")
+		polymer.Do(func() {
+			data.Text = "This text is ALSO set from Go, in a goroutine, after 5 seconds"
+			data.NotifyField(&data.Text)
+		})
 	}()
 
 	// Start a 2nd goroutine to update the live clock, this code is identical to the computed-property example
 	go func() {
 		for {
-			// Set the clock
-			data.Clock = time.Now()
-
-			// Notify
-			data.Notify("clock")
+			// Set the clock and notify
+			polymer.Do(func() {
+				data.Clock = time.Now()
+				data.NotifyField(&data.Clock)
+			})
 
 			// Wait
 			time.Sleep(time.Millisecond * 100)
@@ -72,6 +72,16 @@ func getJsName(fieldName string) string {
 	return newFieldName
 }
 
+// getFieldJsName returns the javascript name of a struct field
+// This is the name set through the name or attribute tag options if present, and the field name passed through getJsName otherwise
+func getFieldJsName(field reflect.StructField) string {
+	if name := parseTag(field).name; name != "" {
+		return name
+	}
+
+	return getJsName(field.Name)
+}
+
 func getJsType(t reflect.Type) *js.Object {
 	switch t.Kind() {
 	case reflect.String:
@@ -19,6 +19,16 @@ limitations under the License.
 //
 // The polymer website itself also has a great deal of information on how to get started, most of the information available there
 // also applies to the Go bindings of polymer.
+//
+// GopherJS runs all goroutines on the single javascript thread, switching between them only when a goroutine blocks.
+// All callbacks made by the library (lifecycle callbacks, handlers, compute functions, observers and exported methods not returning an error)
+// run synchronously on that thread and can't be interrupted by a goroutine, so they are serialized with respect to each other.
+// A goroutine that mutates bound fields and calls Notify can however block halfway through, allowing observers to write to the same fields in between.
+// Use Do or Proto.Dispatch to run such updates from goroutines, they queue the work onto the javascript event loop,
+// where it runs in order and is serialized with all library callbacks.
+//
+// The exception are exported methods returning an error, which may block and therefore run in a goroutine of their own.
+// Like any goroutine, they should use Do to touch bound fields, only the resolution of their promise is queued through Do by the library.
 package polymer
 
 import (
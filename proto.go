@@ -17,6 +17,9 @@ limitations under the License.
 package polymer
 
 import (
+	"fmt"
+	"reflect"
+	"strconv"
 	"strings"
 
 	"github.com/gopherjs/gopherjs/js"
@@ -59,6 +62,18 @@ type Proto struct {
 	this *js.Object
 	Element
 	ready bool
+	self  Interface
+	def   *ElementDefinition
+
+	// notifying holds the paths for which a Notify call is currently in progress
+	notifying []string
+
+	// updateDepth is the number of Update calls currently in progress, pending holds the paths notified during them
+	updateDepth int
+	pending     []string
+
+	// snapshot holds the state of the bound fields as polymer last saw it, for elements implementing DirtyChecker
+	snapshot map[string]interface{}
 }
 
 func (p *Proto) Extends() string { return "" }
@@ -75,18 +90,269 @@ func (p *Proto) data() *Proto { return p }
 func (p *Proto) This() *js.Object { return p.this }
 
 // Notify notifies polymer that a value has changed
+// Top-level readOnly properties are set through their private polymer setter, so Go elements can publish readOnly outputs
+// Inside an Update call, the paths are only notified once the outermost Update call returns
 func (p *Proto) Notify(paths ...string) {
+	if p.updateDepth > 0 {
+		for _, path := range paths {
+			p.pending = addPendingPath(p.pending, path)
+		}
+		return
+	}
+
 	for _, path := range paths {
-		refVal := getRefValForPath(lookupProto(p.this), strings.Split(path, "."))
+		refVal := getRefValForPath(p.self, strings.Split(path, "."))
 		jsObj, _ := encodeRaw(refVal)
 		p.doNotify(path, jsObj)
 	}
 }
 
+// NotifyField notifies polymer that the values the passed pointers point to have changed, e.g. p.NotifyField(&p.Foo.Bar)
+// The paths are derived from the location of the pointers inside the element, so they always match the names polymer uses
+// Pointers to bound fields, to struct fields nested in them and to slice elements are supported, as well as pointers stored in bound fields
+// Pointers to anything else, such as unbound fields, are rejected with an error, nothing is notified in that case
+func (p *Proto) NotifyField(fields ...interface{}) error {
+	paths, err := pathsForPointers(reflect.ValueOf(p.self).Elem(), fields, true)
+	if err != nil {
+		return err
+	}
+
+	p.Notify(paths...)
+	return nil
+}
+
+// Set assigns value to the Go value at path and notifies polymer of the change
+// value is converted to the type of the Go value where Go allows it, so an int can be set on a float64 field for example
+// An error is returned if the path is invalid or value can't be assigned, nothing is changed in that case
+func (p *Proto) Set(path string, value interface{}) error {
+	refVal, err := lookupPath(p.self, strings.Split(path, "."))
+	if err != nil {
+		return err
+	}
+	if !refVal.CanSet() {
+		return fmt.Errorf("Path '%v' refers to a value that can't be set", path)
+	}
+
+	val, err := convertValue(value, refVal.Type())
+	if err != nil {
+		return fmt.Errorf("Can't set path '%v': %v", path, err)
+	}
+
+	refVal.Set(val)
+	p.Notify(path)
+	return nil
+}
+
+// Get decodes the current javascript value at path into out, which should be a pointer
+// Unlike the Go side fields, this reflects changes made by javascript that haven't been observed yet, and works for paths without a Go field
+func (p *Proto) Get(path string, out interface{}) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("Error while getting path '%v': %v", path, r)
+		}
+	}()
+
+	return Decode(p.this.Call("get", path), out)
+}
+
+// LinkPaths makes the path to an alias of the path from, on both the Go and the javascript side, e.g. p.LinkPaths("selected", "items.3")
+// Array indices in from are converted to the collection keys polymer uses in its notifications, so the example links selected to items.#3
+// The Go value at from is assigned to the one at to, after which polymer forwards changes under either path to the other one
+// The forwarded changes reach the Go side through the observers of both paths, so both should be bound for the Go values to stay consistent
+func (p *Proto) LinkPaths(to, from string) error {
+	fromVal, err := lookupPath(p.self, strings.Split(from, "."))
+	if err != nil {
+		return err
+	}
+	toVal, err := lookupPath(p.self, strings.Split(to, "."))
+	if err != nil {
+		return err
+	}
+	if !toVal.CanSet() || !fromVal.Type().AssignableTo(toVal.Type()) {
+		return fmt.Errorf("Can't link path '%v' of type %v to path '%v' of type %v", to, toVal.Type(), from, fromVal.Type())
+	}
+
+	// Make both sides refer to the same value, on the javascript side the path has to hold the very same object for polymer to link it
+	toVal.Set(fromVal)
+	p.doNotify(to, p.this.Call("get", from))
+	p.this.Call("linkPaths", to, p.collectionPath(from))
+
+	return nil
+}
+
+// collectionPath replaces the array indices in path by the polymer collection keys of the items, e.g. items.3.name becomes items.#3.name
+// Polymer notifies changes to array items under their collection key, so only such paths match those notifications
+func (p *Proto) collectionPath(path string) string {
+	collection := js.Global.Get("Polymer").Get("Collection")
+	if collection == js.Undefined {
+		return path
+	}
+
+	parts := strings.Split(path, ".")
+	for i := 1; i < len(parts); i++ {
+		index, err := strconv.Atoi(parts[i])
+		if err != nil {
+			continue
+		}
+
+		array := p.this.Call("get", strings.Join(parts[:i], "."))
+		if array == nil || array == js.Undefined || !js.Global.Get("Array").Call("isArray", array).Bool() {
+			continue
+		}
+
+		if key := collection.Call("get", array).Call("getKey", array.Index(index)); key != nil && key != js.Undefined {
+			parts[i] = key.String()
+		}
+	}
+
+	return strings.Join(parts, ".")
+}
+
+// UnlinkPaths removes the link set up by LinkPaths for path, the Go value at path keeps its current value
+func (p *Proto) UnlinkPaths(path string) {
+	p.this.Call("unlinkPaths", path)
+}
+
+// convertValue converts value to refType, nil is converted to the zero value of refType
+// Conversions from integers to strings are refused, as Go would interpret the integer as a rune
+func convertValue(value interface{}, refType reflect.Type) (reflect.Value, error) {
+	if value == nil {
+		return reflect.Zero(refType), nil
+	}
+
+	val := reflect.ValueOf(value)
+	if val.Type().AssignableTo(refType) {
+		return val, nil
+	}
+
+	isInt := val.Kind() >= reflect.Int && val.Kind() <= reflect.Uintptr
+	if val.Type().ConvertibleTo(refType) && !(isInt && refType.Kind() == reflect.String) {
+		return val.Convert(refType), nil
+	}
+
+	return reflect.Value{}, fmt.Errorf("Value of type %T can't be assigned to a value of type %v", value, refType)
+}
+
+// Update runs f and notifies polymer of all paths passed to Notify inside f in one go, once f returns
+// Every path is only notified once, even if it was passed to Notify multiple times, and paths below another notified path are skipped
+// When the element supports setProperties (polymer 2.0 and up), all paths are passed to it as a single update, so derived bindings and observers run once
+// On older versions of polymer batching is best-effort: the de-duplicated paths are notified one by one, so observers still run once for every notified path they depend on
+// Calls to Update can be nested, in which case everything is flushed when the outermost call returns
+func (p *Proto) Update(f func()) {
+	p.updateDepth++
+	defer func() {
+		p.updateDepth--
+		if p.updateDepth == 0 {
+			paths := p.pending
+			p.pending = nil
+			p.flush(paths)
+		}
+	}()
+
+	f()
+}
+
+// flush notifies polymer of all the passed paths, as a single setProperties call where polymer supports it
+func (p *Proto) flush(paths []string) {
+	if len(paths) < 2 || p.this.Get("setProperties") == js.Undefined {
+		p.Notify(paths...)
+		return
+	}
+
+	props := js.M{}
+	for _, path := range paths {
+		props[path], _ = encodeRaw(getRefValForPath(p.self, strings.Split(path, ".")))
+	}
+
+	p.notifying = append(p.notifying, paths...)
+	defer func() { p.notifying = p.notifying[:len(p.notifying)-len(paths)] }()
+	defer func() {
+		for _, path := range paths {
+			p.refreshSnapshot(path)
+		}
+	}()
+
+	// The second argument allows setting readOnly properties, the same way Notify does through their private setters
+	p.this.Call("setProperties", props, true)
+}
+
+// addPendingPath adds path to the pending paths, unless it's already covered by one of them
+// Pending paths below path are removed, as notifying path already covers them
+func addPendingPath(pending []string, path string) []string {
+	var kept []string
+	for _, curr := range pending {
+		if curr == path || strings.HasPrefix(path, curr+".") {
+			return pending
+		}
+		if !strings.HasPrefix(curr, path+".") {
+			kept = append(kept, curr)
+		}
+	}
+
+	return append(kept, path)
+}
+
 func (p *Proto) doNotify(path string, val interface{}) {
+	p.notifying = append(p.notifying, path)
+	defer func() { p.notifying = p.notifying[:len(p.notifying)-1] }()
+	defer p.refreshSnapshot(path)
+
+	// readOnly properties can't be changed through set(), polymer generates a private _setFoo setter for them instead
+	if p.def != nil && p.def.readOnly[path] {
+		p.this.Call("_set"+strings.ToUpper(path[:1])+path[1:], val)
+		return
+	}
+
 	p.this.Call("set", path, val)
 }
 
+// isNotifying returns true if a Notify call that affects path is currently in progress
+func (p *Proto) isNotifying(path string) bool {
+	for _, curr := range p.notifying {
+		if curr == path || strings.HasPrefix(curr, path+".") || strings.HasPrefix(path, curr+".") {
+			return true
+		}
+	}
+
+	return false
+}
+
 func (p *Proto) Fire(event string, val interface{}) {
 	p.this.Call("fire", event, val)
 }
+
+// FireOptions holds the options for FireWithOptions, the zero value fires the event the same way Fire does
+type FireOptions struct {
+	// NoBubble stops the event from bubbling, events bubble by default like they do with Fire
+	NoBubble   bool
+	Cancelable bool
+
+	// Composed makes the event cross shadow DOM boundaries, it's only supported by polymer 2.0 and up
+	Composed bool
+
+	// Node is the node to fire the event on, if nil, the event is fired on the element itself
+	Node Element
+}
+
+// FireWithOptions fires an event with the given options, and returns the event after all listeners have run
+// This allows implementing cancelable events, by checking DefaultPrevented on the returned event
+// The event is always fired, an error is only returned if it couldn't be decoded afterwards
+func (p *Proto) FireWithOptions(event string, val interface{}, options FireOptions) (*Event, error) {
+	jsOptions := js.M{
+		"bubbles":    !options.NoBubble,
+		"cancelable": options.Cancelable,
+		"composed":   options.Composed,
+	}
+	if options.Node != nil {
+		jsOptions["node"] = unwrap(options.Node.Underlying())
+	}
+
+	jsEvent := p.this.Call("fire", event, val, jsOptions)
+
+	e := &Event{}
+	if err := Decode(js.Global.Get("Polymer").Call("dom", jsEvent), e); err != nil {
+		return nil, fmt.Errorf("Error while decoding event %v: %v", event, err)
+	}
+
+	return e, nil
+}
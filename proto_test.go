@@ -0,0 +1,45 @@
+/*
+Copyright 2015 Palm Stone Games, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package polymer
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestAddPendingPath(t *testing.T) {
+	tests := []struct {
+		pending []string
+		path    string
+		want    []string
+	}{
+		{nil, "foo", []string{"foo"}},
+		{[]string{"foo"}, "foo", []string{"foo"}},
+		{[]string{"foo"}, "bar", []string{"foo", "bar"}},
+		{[]string{"foo"}, "foo.bar", []string{"foo"}},
+		{[]string{"foo.bar", "foo.baz", "qux"}, "foo", []string{"qux", "foo"}},
+		{[]string{"foo"}, "foobar", []string{"foo", "foobar"}},
+		{[]string{"foobar"}, "foo", []string{"foobar", "foo"}},
+	}
+
+	for _, test := range tests {
+		pending := append([]string(nil), test.pending...)
+		if got := addPendingPath(pending, test.path); !reflect.DeepEqual(got, test.want) {
+			t.Errorf("addPendingPath(%q, %q) = %q, want %q", test.pending, test.path, got, test.want)
+		}
+	}
+}
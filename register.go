@@ -25,14 +25,15 @@ import (
 	"github.com/gopherjs/gopherjs/js"
 )
 
-const protoIndexKey = "_polymer_protoIndex"
+const protoKey = "_polymer_proto"
 
 var (
-	//TODO: Use an opaque object set on this instead of a slice, the slice doesn't allow the proto nor js object to ever get freed
-	jsMap                  []Interface
+	liveInstances          int
+	instanceRegistry       *js.Object
 	webComponentsReady     = false
 	pendingGoRegistrations = make(map[string]js.M)
 	pendingJSRegistrations []string
+	registeredTags         = make(map[string]bool)
 	onReadyChans           []chan struct{}
 )
 
@@ -42,8 +43,94 @@ type CustomRegistrationAttr struct {
 	Value interface{}
 }
 
+// Defaulter can be implemented by prototypes to provide default values for their properties
+// Defaults returns a map of javascript property names to the Go values they should default to
+// The values are encoded anew for every element instance, so slices and structs are never shared between instances
+type Defaulter interface {
+	Defaults() map[string]interface{}
+}
+
+// Listener can be implemented by prototypes to declare event listeners on the host element
+// Listeners returns a map of event names to the names of the Handle methods or handler channels that should receive them
+// Event names can be prefixed with the id of a node in the local DOM to listen on that node instead, e.g. `submit.tap`
+type Listener interface {
+	Listeners() map[string]string
+}
+
+// HostAttributer can be implemented by prototypes to declare attributes that are set on the host element when it's created
+type HostAttributer interface {
+	HostAttributes() map[string]interface{}
+}
+
+// KeyBinder can be implemented by prototypes to declare keyboard shortcuts
+// KeyBindings returns a map of key combinations in the IronA11yKeysBehavior format (e.g. `ctrl+s enter`) to the names of the Handle methods that should be called for them
+// Handle methods can accept a *KeyboardEvent to receive the details of the key press
+// Elements with key bindings get IronA11yKeysBehavior added to their behaviors, so iron-a11y-keys-behavior must be imported
+type KeyBinder interface {
+	KeyBindings() map[string]string
+}
+
+// Extender can be implemented by prototypes to extend a native element, such as `input` or `template`
+// Proto implements it by extending nothing, so only types that override Extends() are affected
+type Extender interface {
+	Extends() string
+}
+
+// BehaviorProvider can be implemented by prototypes to declare the behaviors they use
+// The returned values are interpreted the same way as the ones passed to WithBehaviors
+type BehaviorProvider interface {
+	Behaviors() []interface{}
+}
+
+// ObserverProvider can be implemented by prototypes to declare complex observers, such as `ObserveUser(user.*, config)`
+// The referenced methods are called with their decoded arguments whenever any of the observed paths change
+type ObserverProvider interface {
+	Observers() []string
+}
+
+// Exporter can be implemented by prototypes to make methods callable from javascript, e.g. `el.open()` or `el.refresh(id)`
+// Exports returns the names of the methods to export, they're exported under their javascript name (Open becomes open)
+// Arguments are decoded the same way they are for Handle and Compute methods, and the first return value is encoded as the result
+// Methods whose last return value is an error are run in a goroutine and return a Promise instead, so they're allowed to block
+// The Promise resolves with the encoded first return value, or is rejected if a non-nil error was returned
+type Exporter interface {
+	Exports() []string
+}
+
+// ElementDefinition holds the result of analyzing a Go type through Register or RegisterBehavior
+// It can be passed to WithBehaviors, and be inspected through its accessor methods
 type ElementDefinition struct {
-	protoDef js.M
+	protoDef       js.M
+	refType        reflect.Type
+	key            string
+	observerPrefix string
+
+	// behaviorName is the name of the global the definition is published under if it was registered through RegisterBehavior
+	behaviorName string
+
+	// readOnly holds the javascript names of all readOnly properties
+	readOnly map[string]bool
+
+	// syncedFields holds the bound fields that are dirty checked, for protos implementing DirtyChecker
+	syncedFields map[string]bool
+	autoSync     bool
+
+	// Information exposed through the accessor methods
+	// names maps every name claimed on the javascript prototype to a description of what claimed it
+	names map[string]string
+
+	// problems holds everything wrong with the definition that was found while building it, see Validate
+	// findings holds the issues reported by the stricter checks, which only make RegisterE and Validate fail, Register logs them instead
+	problems []string
+	findings []string
+
+	tagName    string
+	properties []PropertyInfo
+	handlers   []string
+	computes   []string
+	exports    []string
+	observers  []string
+	behaviors  []interface{}
 }
 
 func init() {
@@ -52,15 +139,101 @@ func init() {
 
 	// Listen to the WebComponentsReady callback to actually register our events
 	js.Global.Get("window").Call("addEventListener", "WebComponentsReady", webComponentsReadyCallback)
+
+	// Keep track of the number of live instances where the browser allows us to
+	if registry := js.Global.Get("FinalizationRegistry"); registry != js.Undefined {
+		instanceRegistry = registry.New(func() { liveInstances-- })
+	}
 }
 
 func lookupProto(obj *js.Object) Interface {
-	index := obj.Get(protoIndexKey)
-	if index == js.Undefined || index == nil {
-		panic("protoIndexKey not found")
+	return lookupInstance(obj, protoKey)
+}
+
+// storeInstance stores the Go instance proto on obj under key
+// The instance is stored on obj rather than in a Go side map, so it's freed along with obj once the node is garbage collected
+// It's kept in a plain object under __internal_object__, which Interface() unwraps the same way it does for js.MakeWrapper,
+// without the javascript function js.MakeWrapper creates for every method of the instance
+func storeInstance(obj *js.Object, key string, proto Interface) {
+	holder := js.Global.Get("Object").New()
+	holder.Set("__internal_object__", js.InternalObject(proto))
+	obj.Set(key, holder)
+
+	liveInstances++
+	if instanceRegistry != nil {
+		instanceRegistry.Call("register", obj, nil)
+	}
+}
+
+// lookupInstance returns the Go instance stored under key on obj
+// Elements store their instance under protoKey, behaviors each use their own key
+func lookupInstance(obj *js.Object, key string) Interface {
+	proto, ok := findInstance(obj, key)
+	if !ok {
+		panic(fmt.Sprintf("%v not found", key))
+	}
+
+	return proto
+}
+
+// findInstance returns the Go instance stored under key on obj, if any
+func findInstance(obj *js.Object, key string) (Interface, bool) {
+	holder := obj.Get(key)
+	if holder == js.Undefined || holder == nil {
+		return nil, false
+	}
+
+	proto, ok := holder.Interface().(Interface)
+	return proto, ok
+}
+
+// LiveInstances returns the number of Go element and behavior instances whose node hasn't been garbage collected yet
+// It's meant for debugging leaks, instances are released by the garbage collector along with their node, not when it's detached, as it might be attached again
+// The count relies on FinalizationRegistry to find out about collected nodes, ok is false in browsers without it, where the count only goes up
+func LiveInstances() (count int, ok bool) {
+	return liveInstances, instanceRegistry != nil
+}
+
+// InstanceOf returns the Go instance behind el, if el is an element registered through Register
+// This allows calling methods directly on other Go elements, for example after looking them up with QuerySelector
+func InstanceOf(el Element) (Interface, bool) {
+	if el == nil {
+		return nil, false
+	}
+
+	obj := unwrap(el.Underlying())
+	if obj == nil || obj == js.Undefined {
+		return nil, false
+	}
+
+	return findInstance(obj, protoKey)
+}
+
+// InstanceAs stores the Go instance behind el in the variable target points to, if it has the type of that variable
+// Returns false if el isn't a Go element, or if it's an element of another type
+//
+//	var other *MyElement
+//	if polymer.InstanceAs(el, &other) {
+//		other.DoSomething()
+//	}
+func InstanceAs(el Element, target interface{}) bool {
+	targetVal := reflect.ValueOf(target)
+	if targetVal.Kind() != reflect.Ptr || targetVal.IsNil() {
+		panic(fmt.Sprintf("Expected target to be a non-nil pointer, got %T", target))
+	}
+
+	instance, ok := InstanceOf(el)
+	if !ok {
+		return false
 	}
 
-	return jsMap[index.Int()]
+	instanceVal := reflect.ValueOf(instance)
+	if !instanceVal.Type().AssignableTo(targetVal.Elem().Type()) {
+		return false
+	}
+
+	targetVal.Elem().Set(instanceVal)
+	return true
 }
 
 // WithExtends can be passed as option to Register to make an element extend another element
@@ -85,56 +258,299 @@ func WithBehaviors(behaviors ...interface{}) CustomRegistrationAttr {
 // Register makes polymer aware of a certain type
 // Polymer will analyze the type and use it for the tag returned by TagName()
 // The type will then be instantiated automatically when tags corresponding to TagName are created through any method
+//
+// Fields are exposed as polymer properties through the `polymer` struct tag, which accepts the following options:
+//   - bind: exposes the field as a notifying property, changes made on the JS side are decoded into the field
+//   - computed=ComputeFoo(a,b): exposes the field as a computed property, calculated by the given Compute method
+//   - observer=OnFoo: calls the OnFoo(newVal, oldVal) method when the property is changed from the JS side
+//   - name=fooBar or attribute=foo-bar: overrides the javascript name of the property
+//   - type=Array: overrides the javascript type of the property
+//   - value=<json>: sets the JSON encoded default value of the property, a Defaults() method can be used instead
+//   - notify=false, readOnly, reflectToAttribute: set the corresponding flags on the property
+//   - handler: marks a channel field as event handler
+//   - listen=tap: used together with handler, listens to the given event on the host element
+//
+// Register can also be called after WebComponentsReady has triggered, for example by lazily loaded code
+// In that case the element is registered with polymer right away, unless PolymerGo() calls made before are still waiting for their Register call,
+// in which case it's registered after them, so the order of the PolymerGo() calls is kept, see PendingRegistrations
+// Elements with a template should have their HTML import loaded before Register is called late, so polymer can find the template
+//
+// Register panics if the definition can't be registered, other issues found with it (such as unknown tag options or fields with unsupported types) are logged to the console
+// Use RegisterE to get all problems and issues returned as an error instead
 func Register(tagName string, proto Interface, customAttrs ...CustomRegistrationAttr) *ElementDefinition {
+	def, err := registerElement(definitions, tagName, proto, customAttrs, false)
+	if err != nil {
+		panic(err.Error())
+	}
+
+	def.logFindings(tagName)
+	return def
+}
+
+// RegisterE registers a new element like Register does, but returns an error listing all problems with the definition instead of panicking
+// Unlike Register, it also fails for the issues Register only logs, nothing is registered when an error is returned
+func RegisterE(tagName string, proto Interface, customAttrs ...CustomRegistrationAttr) (*ElementDefinition, error) {
+	return registerElement(definitions, tagName, proto, customAttrs, true)
+}
+
+// registerElement registers a new element and records its definition in registry
+// Elements the library registers for its own use are kept in internalDefinitions, so they don't show up in Registered
+func registerElement(registry map[string]*ElementDefinition, tagName string, proto Interface, customAttrs []CustomRegistrationAttr, strict bool) (*ElementDefinition, error) {
+	def, err := newElementDefinition(tagName, proto, customAttrs, strict)
+	if err != nil {
+		return nil, err
+	}
+
+	// Register our prototype with polymer
+	m := def.protoDef
+	def.tagName = tagName
+	registry[tagName] = def
+	pendingGoRegistrations[tagName] = m
 	if webComponentsReady {
-		panic("polymer.Register call after WebComponentsReady has triggered")
+		// Elements without a PolymerGo() call are queued as well, so they're registered after the PolymerGo() calls still pending
+		if !isPendingJS(tagName) {
+			pendingJSRegistrations = append(pendingJSRegistrations, tagName)
+		}
+		flushJSRegistrations()
 	}
 
+	return def, nil
+}
+
+// newElementDefinition builds the definition of an element without registering it, returning an error listing all problems found along the way
+// When strict is set, the findings of the stricter checks are treated as problems as well
+func newElementDefinition(tagName string, proto Interface, customAttrs []CustomRegistrationAttr, strict bool) (*ElementDefinition, error) {
+	var problems, findings []string
 	if !strings.Contains(tagName, "-") {
-		panic("Tagnames must contain a dash according to polymer's standards for custom elements")
+		problems = append(problems, fmt.Sprintf("Tagname '%v' must contain a dash according to polymer's standards for custom elements", tagName))
+	} else if err := validateTagName(tagName); err != nil {
+		findings = append(findings, err.Error())
+	}
+	if definitions[tagName] != nil || internalDefinitions[tagName] != nil {
+		problems = append(problems, fmt.Sprintf("'%v' has already been registered", tagName))
 	}
 
 	// Type detection
+	refType, err := protoType(proto)
+	if err != nil {
+		return nil, &ValidationError{Name: tagName, Problems: append(problems, err.Error())}
+	}
+
+	// Setup basics
+	def := newDefinition(refType, protoKey, "observe")
+	def.problems = problems
+	def.findings = findings
+	m := def.protoDef
+	m["is"] = tagName
+	behaviors := buildDefinition(def, proto)
+
+	// Custom attributes, behaviors are added to the ones we need ourselves rather than replacing them
+	for _, attr := range customAttrs {
+		if attr.Name == "behaviors" {
+			custom, ok := attr.Value.([]interface{})
+			if !ok {
+				def.problemf("The behaviors attribute should be a []interface{}, got %T", attr.Value)
+				continue
+			}
+
+			validateBehaviors(def, custom)
+			behaviors = append(behaviors, custom...)
+			continue
+		}
+
+		m[attr.Name] = attr.Value
+	}
+
+	if len(behaviors) != 0 {
+		m["behaviors"] = behaviors
+		def.behaviors = append([]interface{}(nil), behaviors...)
+	}
+
+	if problems := def.allProblems(strict); len(problems) != 0 {
+		return nil, &ValidationError{Name: tagName, Problems: problems}
+	}
+
+	return def, nil
+}
+
+// protoType returns the type of proto, after checking it's a pointer to a struct
+func protoType(proto interface{}) (reflect.Type, error) {
 	refType := reflect.TypeOf(proto)
-	if refType.Kind() != reflect.Ptr {
-		panic("Expected proto to be a pointer to a struct")
+	if refType == nil || refType.Kind() != reflect.Ptr || refType.Elem().Kind() != reflect.Struct {
+		return nil, fmt.Errorf("Expected proto to be a pointer to a struct, got %T", proto)
+	}
+
+	return refType, nil
+}
+
+// newDefinition creates an empty definition for the passed type
+// The Go instances belonging to the definition are stored on their javascript object under key, observer functions are prefixed with observerPrefix
+func newDefinition(refType reflect.Type, key, observerPrefix string) *ElementDefinition {
+	return &ElementDefinition{
+		protoDef:       js.M{},
+		refType:        refType,
+		key:            key,
+		observerPrefix: observerPrefix,
+		names:          make(map[string]string),
+	}
+}
+
+// claimName records that name is used on the javascript prototype by origin
+func (def *ElementDefinition) claimName(name, origin string) {
+	if existing, ok := def.names[name]; ok {
+		def.findingf("%v and %v both use the name %v", existing, origin, name)
+		return
+	}
+
+	def.names[name] = origin
+}
+
+// problemf records a problem with the definition, problems are reported all at once after building the definition
+func (def *ElementDefinition) problemf(format string, args ...interface{}) {
+	def.problems = append(def.problems, fmt.Sprintf(format, args...))
+}
+
+// findingf records an issue found by the stricter checks, see findings
+func (def *ElementDefinition) findingf(format string, args ...interface{}) {
+	def.findings = append(def.findings, fmt.Sprintf(format, args...))
+}
+
+// allProblems returns the problems that prevent registering the definition, including the findings when strict is set
+func (def *ElementDefinition) allProblems(strict bool) []string {
+	if !strict {
+		return def.problems
 	}
-	if refType.Elem().Kind() != reflect.Struct {
-		panic("Expected proto to be a pointer to a struct")
+
+	return append(append([]string(nil), def.problems...), def.findings...)
+}
+
+// logFindings logs the findings of the stricter checks to the console, for definitions registered without them
+func (def *ElementDefinition) logFindings(name string) {
+	for _, finding := range def.findings {
+		js.Global.Get("console").Call("warn", fmt.Sprintf("%v: %v", name, finding))
+	}
+}
+
+// setFunc sets a function on the javascript prototype, after claiming its name for origin
+func (def *ElementDefinition) setFunc(name string, fn *js.Object, origin string) {
+	def.claimName(name, origin)
+	def.protoDef[name] = fn
+}
+
+// polymerBaseHas returns true if name is already defined on Polymer.Base, which every element prototype inherits from
+func polymerBaseHas(name string) bool {
+	polymer := js.Global.Get("Polymer")
+	if polymer == js.Undefined || polymer.Get("Base") == js.Undefined {
+		return false
 	}
 
+	return polymer.Get("Base").Get(name) != js.Undefined
+}
+
+// buildDefinition analyzes the type of proto and fills in the javascript prototype of def accordingly
+// It returns the behaviors needed by the prototype, it's up to the caller to add them to the prototype
+func buildDefinition(def *ElementDefinition, proto interface{}) []interface{} {
+	m := def.protoDef
+	refType := def.refType
+
 	// Setup basics
-	m := js.M{}
-	m["is"] = tagName
-	m["created"] = createdCallback(refType)
-	m["ready"] = readyCallback()
-	m["attached"] = attachedCallback()
-	m["detached"] = detachedCallback()
-	m["properties"] = parseProperties(refType)
+	def.setFunc("created", createdCallback(def), "lifecycle callback created")
+	def.setFunc("ready", readyCallback(def.key), "lifecycle callback ready")
+	def.setFunc("attached", attachedCallback(def.key), "lifecycle callback attached")
+	def.setFunc("detached", detachedCallback(def.key), "lifecycle callback detached")
+	m["properties"] = parseProperties(def, protoDefaults(proto))
+	for _, prop := range def.properties {
+		def.claimName(prop.Name, "property "+prop.Field)
+	}
+
+	def.readOnly = make(map[string]bool)
+	for _, prop := range def.properties {
+		if prop.ReadOnly {
+			def.readOnly[prop.Name] = true
+		}
+	}
 
 	// Setup handlers
 	for _, handler := range parseHandlers(refType) {
-		m[getJsName(handler.Name)] = eventHandlerCallback(handler.Func)
+		def.setFunc(getJsName(handler.Name), eventHandlerCallback(def.key, handler.Func), "handler "+handler.Name)
+		def.handlers = append(def.handlers, getJsName(handler.Name))
+	}
+	for _, handler := range parseChanHandlers(refType) {
+		def.claimName(getJsName(handler.Name), "handler channel "+handler.Name)
+		def.handlers = append(def.handlers, getJsName(handler.Name))
 	}
 
 	// Setup compute functions
 	for _, handler := range parseComputes(refType) {
-		m[getJsName(handler.Name)] = computeCallback(handler.Func)
+		def.setFunc(getJsName(handler.Name), computeCallback(def.key, handler.Func), "compute function "+handler.Name)
+		def.computes = append(def.computes, getJsName(handler.Name))
+	}
+
+	// Setup exported methods
+	if exporter, ok := proto.(Exporter); ok {
+		for _, name := range exporter.Exports() {
+			method, ok := refType.MethodByName(name)
+			if !ok {
+				def.problemf("Exported method %v does not exist on %v", name, refType)
+				continue
+			}
+
+			jsName := getJsName(method.Name)
+			if polymerBaseHas(jsName) {
+				def.findingf("Exported method %v would override %v of Polymer.Base", method.Name, jsName)
+			}
+			def.setFunc(jsName, exportCallback(def.key, method.Func), "exported method "+method.Name)
+			def.exports = append(def.exports, jsName)
+		}
 	}
 
 	// Note: Channel based event handlers are not setup here, they're setup in Created() as we need to actually make the channels
 
-	// Setup observers
-	setObservers(refType, m)
+	// Setup host listeners and attributes
+	m["listeners"] = parseListeners(def, proto)
+	if hostAttributer, ok := proto.(HostAttributer); ok {
+		m["hostAttributes"] = js.M(hostAttributer.HostAttributes())
+	}
 
-	// Custom attributes
-	for _, attr := range customAttrs {
-		m[attr.Name] = attr.Value
+	// Setup key bindings
+	var behaviors []interface{}
+	if keyBinder, ok := proto.(KeyBinder); ok {
+		m["keyBindings"] = parseKeyBindings(def, keyBinder.KeyBindings())
+		behaviors = append(behaviors, "IronA11yKeysBehavior")
 	}
 
-	// Register our prototype with polymer
-	pendingGoRegistrations[tagName] = m
-	return &ElementDefinition{m}
+	// Setup prototype level configuration
+	if extender, ok := proto.(Extender); ok && extender.Extends() != "" {
+		m["extends"] = extender.Extends()
+	}
+	if behaviorProvider, ok := proto.(BehaviorProvider); ok {
+		behaviors = append(behaviors, behaviorProvider.Behaviors()...)
+	}
+
+	// Setup dirty checking, computed fields are left out as their value is owned by polymer
+	if dirtyChecker, ok := proto.(DirtyChecker); ok {
+		def.syncedFields = make(map[string]bool)
+		def.autoSync = dirtyChecker.AutoSync()
+		for _, prop := range def.properties {
+			if prop.Computed == "" {
+				def.syncedFields[prop.Name] = true
+			}
+		}
+	}
+
+	// Setup observers
+	setObservers(def)
+	if observerProvider, ok := proto.(ObserverProvider); ok {
+		m["observers"] = append(m["observers"].(js.S), parseObserverExprs(def, observerProvider.Observers())...)
+	}
+	for _, observer := range m["observers"].(js.S) {
+		def.observers = append(def.observers, observer.(string))
+	}
+
+	validateFields(def)
+	validateBehaviors(def, behaviors)
+
+	return behaviors
 }
 
 // OnReady returns a channel that will be closed once polymer has been initialized
@@ -147,152 +563,281 @@ func OnReady() <-chan struct{} {
 func webComponentsReadyCallback() {
 	webComponentsReady = true
 
-	// Get all tag names for our Go side registrations
-	goTagNames := make([]string, len(pendingGoRegistrations))
-	i := 0
-	for _, reg := range pendingGoRegistrations {
-		goTagNames[i] = reg["is"].(string)
-		i++
+	// Go only registrations are those without a PolymerGo() call, they're registered after all PolymerGo() calls, sorted by tag name
+	var goOnlyRegistration []string
+	for tagName := range pendingGoRegistrations {
+		if !isPendingJS(tagName) {
+			goOnlyRegistration = append(goOnlyRegistration, tagName)
+		}
 	}
+	sort.Strings(goOnlyRegistration)
 
-	// Setup a copy of the pendingJSRegistrations slice for use with the set package
-	// We don't want to lose our ordering
-	jsTagNames := make([]string, len(pendingJSRegistrations))
-	copy(jsTagNames, pendingJSRegistrations)
+	// Process all JS registrations in order, and then all the go only ones
+	// JS only registrations are kept pending, as lazily loaded Go code might still call polymer.Register() for them, see PendingRegistrations
+	pendingJSRegistrations = append(pendingJSRegistrations, goOnlyRegistration...)
+	flushJSRegistrations()
 
-	sort.Sort(sort.StringSlice(goTagNames))
-	sort.Sort(sort.StringSlice(jsTagNames))
+	// Close all ready chans
+	for _, c := range onReadyChans {
+		close(c)
+	}
+}
 
-	i = 0
-	j := 0
-	var jsOnlyRegistration []string
-	var goOnlyRegistration []string
-	var fullRegistration []string
-	for i < len(goTagNames) && j < len(jsTagNames) {
-		if goTagNames[i] < jsTagNames[j] { // Present in go but not js
-			goOnlyRegistration = append(goOnlyRegistration, goTagNames[i])
-			i++
-		} else if jsTagNames[j] < goTagNames[i] { // Present in js but not go
-			jsOnlyRegistration = append(jsOnlyRegistration, jsTagNames[j])
-			j++
-		} else { // Present in both
-			fullRegistration = append(fullRegistration, goTagNames[i])
-			i++
-			j++
+// flushJSRegistrations registers the queued tags in the order they were queued, which is the order of the PolymerGo() calls
+// It stops at the first tag for which polymer.Register() hasn't been called yet, that tag and the ones after it are kept pending
+func flushJSRegistrations() {
+	for len(pendingJSRegistrations) != 0 {
+		tagName := pendingJSRegistrations[0]
+		if !registeredTags[tagName] {
+			if _, ok := pendingGoRegistrations[tagName]; !ok {
+				return
+			}
+
+			registerTag(tagName)
 		}
-	}
-	for ; i < len(goTagNames); i++ {
-		goOnlyRegistration = append(goOnlyRegistration, goTagNames[i])
-	}
-	for ; j < len(jsTagNames); j++ {
-		jsOnlyRegistration = append(jsOnlyRegistration, jsTagNames[j])
-	}
 
-	// Check for JS only registrations, those are invalid
-	for _, tagName := range jsOnlyRegistration {
-		fmt.Printf("Error: '%v' is registered through PolymerGo(), but polymer.Register() was never called for it.\n", tagName)
-	}
-	if len(jsOnlyRegistration) != 0 {
-		panic("All tags registered through PolymerGo must have polymer.Register() called for them")
+		pendingJSRegistrations = pendingJSRegistrations[1:]
 	}
+}
 
-	// process all JS registrations in order, and then all the go only ones
-	// Loop through the JS registrations and call Polymer()
-	for _, tagName := range pendingJSRegistrations {
-		doRegister(pendingGoRegistrations[tagName])
+// isPendingJS returns true if tagName is queued in pendingJSRegistrations
+func isPendingJS(tagName string) bool {
+	for _, curr := range pendingJSRegistrations {
+		if curr == tagName {
+			return true
+		}
 	}
 
-	// Register Go only elements as well
-	for _, tagName := range goOnlyRegistration {
-		doRegister(pendingGoRegistrations[tagName])
-	}
+	return false
+}
 
-	// Close all ready chans
-	for _, c := range onReadyChans {
-		close(c)
-	}
+// registerTag registers the pending Go registration for tagName with polymer
+func registerTag(tagName string) {
+	doRegister(pendingGoRegistrations[tagName])
+	delete(pendingGoRegistrations, tagName)
+	registeredTags[tagName] = true
 }
 
 func doRegister(protoDef js.M) {
 	if protoDef["behaviors"] != nil {
-		behaviors := protoDef["behaviors"].([]interface{})
-		for i, val := range behaviors {
-			switch val.(type) {
-			case string:
-				name := val.(string)
-				global := js.Global.Get(name)
-				if global != nil && global != js.Undefined {
-					behaviors[i] = global
-				} else {
-					behaviors[i] = js.Global.Get("Polymer").Get(name)
-				}
-			case *ElementDefinition:
-				behaviors[i] = val.(*ElementDefinition).protoDef
-			case *js.Object:
-			default:
-				panic(fmt.Sprintf("Don't know what to do with behavior of type %T", behaviors[i]))
-			}
-		}
-
-		protoDef["behaviors"] = behaviors
+		protoDef["behaviors"] = resolveBehaviors(protoDef["behaviors"].([]interface{}))
 	}
 
 	js.Global.Call("Polymer", protoDef)
 }
 
-func polymerGo(tagName string) {
-	if webComponentsReady {
-		panic("PolymerGo call after WebComponentsReady has triggered")
+// resolveBehaviors converts the values passed to WithBehaviors to the javascript objects polymer expects
+func resolveBehaviors(behaviors []interface{}) []interface{} {
+	for i, val := range behaviors {
+		switch val.(type) {
+		case string:
+			name := val.(string)
+			global := js.Global.Get(name)
+			if global != nil && global != js.Undefined {
+				behaviors[i] = global
+			} else {
+				behaviors[i] = js.Global.Get("Polymer").Get(name)
+			}
+		case *ElementDefinition:
+			behaviors[i] = val.(*ElementDefinition).jsBehavior()
+		case *js.Object:
+		default:
+			panic(fmt.Sprintf("Don't know what to do with behavior of type %T", behaviors[i]))
+		}
 	}
 
+	return behaviors
+}
+
+func polymerGo(tagName string) {
 	if !strings.Contains(tagName, "-") {
 		panic("Tagnames must contain a dash according to polymer's standards for custom elements")
 	}
 
+	if registeredTags[tagName] {
+		js.Global.Get("console").Call("warn", fmt.Sprintf("'%v' was already registered before its PolymerGo() call, its template might not have been picked up", tagName))
+		return
+	}
+
 	pendingJSRegistrations = append(pendingJSRegistrations, tagName)
+
+	// After WebComponentsReady, register right away if the Go side is already known
+	if webComponentsReady {
+		flushJSRegistrations()
+	}
 }
 
-func parseProperties(refType reflect.Type) js.M {
+func protoDefaults(proto interface{}) map[string]interface{} {
+	if defaulter, ok := proto.(Defaulter); ok {
+		return defaulter.Defaults()
+	}
+
+	return nil
+}
+
+// parseProperties builds the polymer properties block for the type of def, and records the properties on def
+func parseProperties(def *ElementDefinition, defaults map[string]interface{}) js.M {
+	rootType := def.refType
 	properties := js.M{}
+	usedDefaults := make(map[string]bool)
 
-	refType = refType.Elem()
-	for i := 0; i < refType.NumField(); i++ {
-		fieldType := refType.Field(i)
-		if fieldType.Anonymous && (fieldType.Type == typeOfPtrProto || fieldType.Type == typeOfPtrBindProto) {
+	for _, fieldType := range parseFields(rootType.Elem()) {
+		tag := parseTag(fieldType)
+		if !tag.bound() {
 			continue
 		}
 
-		tagText := fieldType.Tag.Get("polymer")
-		if tagText == "" {
-			continue
+		jsName := getFieldJsName(fieldType)
+		prop := js.M{
+			"type":   getJsType(fieldType.Type),
+			"notify": tag.notify,
+		}
+
+		if tag.jsType != "" {
+			prop["type"] = js.Global.Get(tag.jsType)
+			if prop["type"] == js.Undefined {
+				def.findingf("Field %v has type option '%v', which is not a global javascript constructor", fieldType.Name, tag.jsType)
+			}
 		}
+		if tag.computed != "" {
+			computed, err := parseComputedExpr(rootType, fieldType, tag.computed)
+			if err != nil {
+				def.problemf("%v", err)
+			}
 
-		tag := strings.Split(tagText, ",")
-		if len(tag) > 0 {
-			switch tag[0] {
-			case "bind":
-				prop := js.M{
-					"type":   getJsType(refType.FieldByIndex(fieldType.Index).Type),
-					"notify": true,
-				}
-
-				for i := 1; i < len(tag); i++ {
-					switch tag[i] {
-					case "reflectToAttribute":
-						prop["reflectToAttribute"] = true
-					case "readOnly":
-						prop["readOnly"] = true
-					}
-				}
-
-				properties[getJsName(fieldType.Name)] = prop
+			prop["computed"] = computed
+		}
+		if tag.reflectToAttribute {
+			prop["reflectToAttribute"] = true
+		}
+		if tag.readOnly {
+			prop["readOnly"] = true
+		}
+
+		// Default values, a Defaults() entry takes precedence over the value tag option
+		if val, ok := defaults[jsName]; ok {
+			prop["value"] = defaultValueFunc(val)
+			usedDefaults[jsName] = true
+		} else if tag.value != "" {
+			if err := checkJSON(tag.value); err != nil {
+				def.findingf("Invalid default value '%v' for field %v: %v", tag.value, fieldType.Name, err)
 			}
+			prop["value"] = defaultJSONFunc(fieldType, tag.value)
+		}
+
+		properties[jsName] = prop
+		def.properties = append(def.properties, PropertyInfo{
+			Name:               jsName,
+			Field:              fieldType.Name,
+			Type:               fieldType.Type,
+			Notify:             tag.notify,
+			ReadOnly:           tag.readOnly,
+			ReflectToAttribute: tag.reflectToAttribute,
+			Computed:           tag.computed,
+			Observer:           tag.observer,
+		})
+	}
+
+	for jsName := range defaults {
+		if !usedDefaults[jsName] {
+			def.problemf("Default value specified for %v, but %v has no bound property with that name", jsName, rootType)
 		}
 	}
 
 	return properties
 }
 
+// defaultValueFunc returns a polymer value function that encodes a fresh copy of val for every instance
+func defaultValueFunc(val interface{}) *js.Object {
+	return js.MakeFunc(func(this *js.Object, arguments []*js.Object) interface{} {
+		jsObj, _ := Encode(val)
+		return jsObj
+	})
+}
+
+// defaultJSONFunc returns a polymer value function that parses the JSON text of a value tag option for every instance
+func defaultJSONFunc(field reflect.StructField, text string) *js.Object {
+	return js.MakeFunc(func(this *js.Object, arguments []*js.Object) (val interface{}) {
+		defer func() {
+			if r := recover(); r != nil {
+				panic(fmt.Sprintf("Invalid default value '%v' for field %v: %v", text, field.Name, r))
+			}
+		}()
+
+		return js.Global.Get("JSON").Call("parse", text)
+	})
+}
+
+// checkJSON returns an error if text can't be parsed as JSON
+func checkJSON(text string) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("%v", r)
+		}
+	}()
+
+	js.Global.Get("JSON").Call("parse", text)
+	return nil
+}
+
+// parseFields returns the fields of the struct type refType, with the fields of embedded structs (mixins) flattened into it the same way Go promotes them
+// The Index of the returned fields is relative to refType, so they can be passed to FieldByIndex directly
+// The embedded *Proto and *BindProto fields are left out, as are embedded structs that carry a polymer tag of their own
+func parseFields(refType reflect.Type) []reflect.StructField {
+	var fields []reflect.StructField
+	var mixins []reflect.StructField
+	names := make(map[string]bool)
+
+	for i := 0; i < refType.NumField(); i++ {
+		field := refType.Field(i)
+		if field.Anonymous && (field.Type == typeOfPtrProto || field.Type == typeOfPtrBindProto) {
+			continue
+		}
+
+		if isMixin(field) {
+			mixins = append(mixins, field)
+		} else {
+			fields = append(fields, field)
+		}
+
+		names[field.Name] = true
+	}
+
+	// Fields from mixins are shadowed by fields at a shallower depth
+	for _, mixin := range mixins {
+		mixinType := mixin.Type
+		if mixinType.Kind() == reflect.Ptr {
+			mixinType = mixinType.Elem()
+		}
+
+		for _, field := range parseFields(mixinType) {
+			if names[field.Name] {
+				continue
+			}
+
+			field.Index = append([]int{mixin.Index[0]}, field.Index...)
+			fields = append(fields, field)
+		}
+	}
+
+	return fields
+}
+
+// isMixin returns true if field is an embedded struct whose fields should be promoted into the embedding element
+// The embedded *Proto and *BindProto are not mixins, their fields are internal to the library
+func isMixin(field reflect.StructField) bool {
+	if !field.Anonymous || field.Tag.Get("polymer") != "" || field.Type == typeOfJsObject || field.Type == typeOfPtrProto || field.Type == typeOfPtrBindProto {
+		return false
+	}
+
+	fieldType := field.Type
+	if fieldType.Kind() == reflect.Ptr {
+		fieldType = fieldType.Elem()
+	}
+
+	return fieldType.Kind() == reflect.Struct && fieldType != typeOfTime
+}
+
 func parseHandlers(refType reflect.Type) []reflect.Method {
 	var handlers []reflect.Method
 
@@ -308,30 +853,80 @@ func parseHandlers(refType reflect.Type) []reflect.Method {
 }
 
 func parseChanHandlers(refType reflect.Type) []reflect.StructField {
-	refType = refType.Elem()
 	var handlers []reflect.StructField
 
-	for i := 0; i < refType.NumField(); i++ {
-		fieldType := refType.Field(i)
-		if fieldType.Anonymous && (fieldType.Type == typeOfPtrProto || fieldType.Type == typeOfPtrBindProto) {
-			continue
+	for _, fieldType := range parseFields(refType.Elem()) {
+		if parseTag(fieldType).handler {
+			handlers = append(handlers, fieldType)
 		}
+	}
 
-		tagText := fieldType.Tag.Get("polymer")
-		if tagText == "" {
-			continue
+	return handlers
+}
+
+// parseListeners builds the polymer listeners block out of the Listeners() method of the proto and the listen option on handler channels
+func parseListeners(def *ElementDefinition, proto interface{}) js.M {
+	refType := def.refType
+	listeners := js.M{}
+
+	// Gather the names of all handlers, so we can check the listeners refer to existing ones
+	handlerNames := make(map[string]bool)
+	for _, handler := range parseHandlers(refType) {
+		handlerNames[getJsName(handler.Name)] = true
+	}
+	for _, handler := range parseChanHandlers(refType) {
+		handlerNames[getJsName(handler.Name)] = true
+	}
+
+	addListener := func(event, handler string) {
+		jsHandler := getJsName(handler)
+		if !handlerNames[jsHandler] {
+			def.problemf("Listener for event %v refers to %v, which is not a handler on %v", event, handler, refType)
+			return
+		}
+		if existing, ok := listeners[event]; ok && existing != jsHandler {
+			def.problemf("Event %v is listened to by both %v and %v on %v", event, existing, jsHandler, refType)
+			return
 		}
 
-		tag := strings.Split(tagText, ",")
-		for i := 0; i < len(tag); i++ {
-			switch tag[i] {
-			case "handler":
-				handlers = append(handlers, fieldType)
-			}
+		listeners[event] = jsHandler
+	}
+
+	if listener, ok := proto.(Listener); ok {
+		for event, handler := range listener.Listeners() {
+			addListener(event, handler)
 		}
 	}
 
-	return handlers
+	for _, handler := range parseChanHandlers(refType) {
+		if listen := parseTag(handler).listen; listen != "" {
+			addListener(listen, handler.Name)
+		}
+	}
+
+	return listeners
+}
+
+// parseKeyBindings converts the key bindings of a proto to the IronA11yKeysBehavior keyBindings block
+func parseKeyBindings(def *ElementDefinition, keyBindings map[string]string) js.M {
+	refType := def.refType
+	handlerNames := make(map[string]bool)
+	for _, handler := range parseHandlers(refType) {
+		handlerNames[getJsName(handler.Name)] = true
+	}
+
+	m := js.M{}
+	for keys, handler := range keyBindings {
+		jsHandler := getJsName(handler)
+		if !handlerNames[jsHandler] {
+			def.problemf("Key binding %v refers to %v, which is not a Handle method on %v", keys, handler, refType)
+			continue
+		}
+
+		m[keys] = jsHandler
+	}
+
+	return m
 }
 
 func parseComputes(refType reflect.Type) []reflect.Method {
@@ -348,13 +943,49 @@ func parseComputes(refType reflect.Type) []reflect.Method {
 	return handlers
 }
 
-func setObservers(refType reflect.Type, m js.M) {
+// parseObserverExprs installs the methods referenced by complex observer expressions and returns the expressions converted to javascript
+func parseObserverExprs(def *ElementDefinition, exprs []string) js.S {
+	refType := def.refType
 	observers := js.S{}
-	setObserversNested(refType.Elem(), m, &observers, nil)
-	m["observers"] = observers
+	installed := make(map[string]bool)
+	for _, expr := range exprs {
+		name, args, ok := splitMethodExpr(expr)
+		if !ok {
+			def.problemf("Observer expression '%v' should have the form ObserveFoo(path1,path2)", expr)
+			continue
+		}
+
+		method, ok := refType.MethodByName(name)
+		if !ok {
+			def.problemf("Observer expression '%v' refers to %v, which is not a method on %v", expr, name, refType)
+			continue
+		}
+		if strings.HasPrefix(method.Name, "Handle") {
+			def.problemf("Observer expression '%v' refers to %v, Handle methods are reserved for events", expr, name)
+			continue
+		}
+
+		// The same method can observe several sets of paths, it only has to be installed once
+		jsName := getJsName(method.Name)
+		if !installed[method.Name] {
+			def.setFunc(jsName, observerMethodCallback(def.key, method.Func), "observer method "+method.Name)
+			installed[method.Name] = true
+		}
+		observers = append(observers, jsName+args)
+	}
+
+	return observers
 }
 
-func setObserversNested(refType reflect.Type, m js.M, observers *js.S, path []string) {
+func setObservers(def *ElementDefinition) {
+	observers := js.S{}
+	setObserversNested(def, def.refType.Elem(), &observers, nil)
+	def.protoDef["observers"] = observers
+}
+
+func setObserversNested(def *ElementDefinition, refType reflect.Type, observers *js.S, path []string) {
+	rootType := def.refType
+
 	for i := 0; i < refType.NumField(); i++ {
 		field := refType.Field(i)
 
@@ -363,16 +994,29 @@ func setObserversNested(refType reflect.Type, m js.M, observers *js.S, path []st
 			continue
 		}
 
+		// Fields of mixins are promoted to the current level
+		if isMixin(field) {
+			mixinType := field.Type
+			if mixinType.Kind() == reflect.Ptr {
+				mixinType = mixinType.Elem()
+			}
+
+			setObserversNested(def, mixinType, observers, path)
+			continue
+		}
+
 		currPath := make([]string, len(path)+1)
 		copy(currPath, path)
-		currPath[len(path)] = getJsName(field.Name)
-
-		// Check if this field has the bind fieldtag
-		bind := false
-		for _, tag := range strings.Split(field.Tag.Get("polymer"), ",") {
-			if tag == "bind" {
-				bind = true
-				break
+		currPath[len(path)] = getFieldJsName(field)
+
+		// Check if this field is bound or computed, and if so, whether a Go side observer method was requested
+		// Computed fields are observed like bound ones, so the computed value ends up in the Go field
+		tag := parseTag(field)
+		var observer reflect.Value
+		if tag.bound() && tag.observer != "" {
+			var err error
+			if observer, err = lookupObserver(rootType, field, tag.observer); err != nil {
+				def.problemf("%v", err)
 			}
 		}
 
@@ -391,21 +1035,21 @@ func setObserversNested(refType reflect.Type, m js.M, observers *js.S, path []st
 		// Use the kind to decide what to do
 		switch fieldType.Kind() {
 		case reflect.Interface, reflect.Struct, reflect.Slice:
-			if bind {
-				funcName, bindStr := pathBind(currPath, "*")
+			if tag.bound() {
+				funcName, bindStr := pathBind(def.observerPrefix, currPath, "*")
 				*observers = append(*observers, bindStr)
-				m[funcName] = observeDeepCallback()
+				def.setFunc(funcName, observeDeepCallback(def.key, currPath, observer), "generated observer for "+strings.Join(currPath, "."))
 			}
 
 			if fieldType.Kind() == reflect.Struct {
-				setObserversNested(fieldType, m, observers, currPath)
+				setObserversNested(def, fieldType, observers, currPath)
 			}
 		default:
 			// Add the current field if bound
-			if bind {
-				funcName, bindStr := pathBind(currPath, "")
+			if tag.bound() {
+				funcName, bindStr := pathBind(def.observerPrefix, currPath, "")
 				*observers = append(*observers, bindStr)
-				m[funcName] = observeShallowCallback(currPath)
+				def.setFunc(funcName, observeShallowCallback(def.key, currPath, observer), "generated observer for "+strings.Join(currPath, "."))
 			}
 		}
 	}
@@ -413,8 +1057,30 @@ func setObserversNested(refType reflect.Type, m js.M, observers *js.S, path []st
 	return
 }
 
-func pathBind(path []string, mode string) (string, string) {
-	funcName := fmt.Sprintf("observe_%v", strings.Join(path, "_"))
+// lookupObserver looks up the observer method with the given name on the root type
+// Observer methods accept up to two arguments of the same type as the field they observe, the new and the old value, in that order
+func lookupObserver(rootType reflect.Type, field reflect.StructField, name string) (reflect.Value, error) {
+	method, ok := rootType.MethodByName(name)
+	if !ok {
+		return reflect.Value{}, fmt.Errorf("Observer method %v for field %v does not exist on %v", name, field.Name, rootType)
+	}
+
+	methodType := method.Type
+	if methodType.NumIn() > 3 || methodType.NumOut() != 0 {
+		return reflect.Value{}, fmt.Errorf("Observer method %v for field %v should have the signature func(newVal, oldVal %v)", name, field.Name, field.Type)
+	}
+
+	for i := 1; i < methodType.NumIn(); i++ {
+		if methodType.In(i) != field.Type {
+			return reflect.Value{}, fmt.Errorf("Observer method %v for field %v should have the signature func(newVal, oldVal %v)", name, field.Name, field.Type)
+		}
+	}
+
+	return method.Func, nil
+}
+
+func pathBind(prefix string, path []string, mode string) (string, string) {
+	funcName := fmt.Sprintf("%v_%v", prefix, strings.Join(path, "_"))
 	bindStr := strings.Join(path, ".")
 
 	if mode != "" {
@@ -0,0 +1,113 @@
+/*
+Copyright 2015 Palm Stone Games, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package polymer
+
+import (
+	"reflect"
+	"sort"
+)
+
+// definitions holds the definitions of all elements registered through Register, by tag name
+var definitions = make(map[string]*ElementDefinition)
+
+// internalDefinitions holds the definitions of the elements the library registers for its own use, such as dom-bind-go
+var internalDefinitions = make(map[string]*ElementDefinition)
+
+// PropertyInfo describes a property declared by a Go element through the polymer struct tag
+type PropertyInfo struct {
+	// Name is the javascript name of the property
+	Name string
+	// Field is the name of the Go field backing the property
+	Field string
+	// Type is the type of the Go field backing the property
+	Type reflect.Type
+
+	Notify             bool
+	ReadOnly           bool
+	ReflectToAttribute bool
+
+	// Computed holds the computed expression of the property, if any
+	Computed string
+	// Observer holds the name of the Go observer method of the property, if any
+	Observer string
+}
+
+// Registered returns the definitions of all elements registered through Register, sorted by tag name
+func Registered() []*ElementDefinition {
+	tagNames := make([]string, 0, len(definitions))
+	for tagName := range definitions {
+		tagNames = append(tagNames, tagName)
+	}
+	sort.Strings(tagNames)
+
+	defs := make([]*ElementDefinition, len(tagNames))
+	for i, tagName := range tagNames {
+		defs[i] = definitions[tagName]
+	}
+
+	return defs
+}
+
+// PendingRegistrations returns the tag names for which PolymerGo() has been called, but polymer.Register() hasn't, in the order of the PolymerGo() calls
+// Elements registered after such a tag are held back until polymer.Register() is called for it, to keep the registration order intact
+func PendingRegistrations() []string {
+	var pending []string
+	for _, tagName := range pendingJSRegistrations {
+		if _, ok := pendingGoRegistrations[tagName]; !ok && !registeredTags[tagName] {
+			pending = append(pending, tagName)
+		}
+	}
+
+	return pending
+}
+
+// Definition returns the definition of the element registered through Register under tagName
+func Definition(tagName string) (*ElementDefinition, bool) {
+	def, ok := definitions[tagName]
+	return def, ok
+}
+
+// TagName returns the tag name the element was registered under, it's empty for definitions made through RegisterBehavior
+func (def *ElementDefinition) TagName() string { return def.tagName }
+
+// BehaviorName returns the name the behavior was published under, it's empty for definitions made through Register
+func (def *ElementDefinition) BehaviorName() string { return def.behaviorName }
+
+// Type returns the Go type implementing the element, this is always a pointer to a struct
+func (def *ElementDefinition) Type() reflect.Type { return def.refType }
+
+// Properties returns the properties declared by the element
+func (def *ElementDefinition) Properties() []PropertyInfo {
+	return append([]PropertyInfo(nil), def.properties...)
+}
+
+// Handlers returns the javascript names of the event handlers of the element, both Handle methods and handler channels
+func (def *ElementDefinition) Handlers() []string { return append([]string(nil), def.handlers...) }
+
+// Computes returns the javascript names of the compute functions of the element
+func (def *ElementDefinition) Computes() []string { return append([]string(nil), def.computes...) }
+
+// Exports returns the javascript names of the methods exported by the element
+func (def *ElementDefinition) Exports() []string { return append([]string(nil), def.exports...) }
+
+// Observers returns the observer expressions of the element, including the ones generated for bound fields
+func (def *ElementDefinition) Observers() []string { return append([]string(nil), def.observers...) }
+
+// Behaviors returns the behaviors used by the element, as passed to WithBehaviors or returned by Behaviors()
+func (def *ElementDefinition) Behaviors() []interface{} {
+	return append([]interface{}(nil), def.behaviors...)
+}
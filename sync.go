@@ -0,0 +1,196 @@
+/*
+Copyright 2015 Palm Stone Games, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package polymer
+
+import (
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gopherjs/gopherjs/js"
+)
+
+// lengthKeySuffix is appended to the path of a slice to form the snapshot key holding its length
+const lengthKeySuffix = ".#length"
+
+// DirtyChecker can be implemented by protos to opt into dirty checking of their bound fields
+// Elements implementing it keep a snapshot of the values polymer has seen, which Sync compares against to find the changed paths
+// When AutoSync returns true, Sync is called automatically after every lifecycle callback, handler, compute function, observer and exported method
+type DirtyChecker interface {
+	AutoSync() bool
+}
+
+// Sync notifies polymer of every path of a bound field that changed since polymer last saw it, including nested struct fields and slice elements
+// Slices that changed length are notified as a whole
+// This is only available for elements implementing DirtyChecker, for other elements Sync does nothing
+func (p *Proto) Sync() {
+	if p.snapshot == nil {
+		return
+	}
+
+	current := p.takeSnapshot()
+	changed := diffSnapshots(p.snapshot, current)
+	p.snapshot = current
+
+	if len(changed) != 0 {
+		p.Update(func() {
+			p.Notify(changed...)
+		})
+	}
+}
+
+// autoSync calls Sync if the element opted into automatic syncing
+func (p *Proto) autoSync() {
+	if p.def != nil && p.def.autoSync {
+		p.Sync()
+	}
+}
+
+// takeSnapshot returns a snapshot of all bound fields that are owned by Go, computed fields are left out as their value comes from polymer
+func (p *Proto) takeSnapshot() map[string]interface{} {
+	snapshot := make(map[string]interface{})
+	refVal := reflect.ValueOf(p.self).Elem()
+	for _, field := range parseFields(refVal.Type()) {
+		if p.def.syncedFields[getFieldJsName(field)] {
+			snapshotValue(snapshot, getFieldJsName(field), refVal.FieldByIndex(field.Index))
+		}
+	}
+
+	return snapshot
+}
+
+// refreshSnapshot updates the snapshot for path and everything below it, after the value at path was synced with polymer
+// Paths going through polymer collection keys (#0) refresh the whole array, as the snapshot is keyed by index
+func (p *Proto) refreshSnapshot(path string) {
+	if p.snapshot == nil || !p.def.syncedFields[strings.SplitN(path, ".", 2)[0]] {
+		return
+	}
+
+	if i := strings.Index(path, ".#"); i != -1 {
+		path = path[:i]
+	}
+
+	for key := range p.snapshot {
+		if key == path || strings.HasPrefix(key, path+".") {
+			delete(p.snapshot, key)
+		}
+	}
+
+	snapshotValue(p.snapshot, path, getRefValForPath(p.self, strings.Split(path, ".")))
+}
+
+// snapshotValue records the value of refVal in snapshot, values that polymer can observe separately get their own key
+func snapshotValue(snapshot map[string]interface{}, path string, refVal reflect.Value) {
+	refType := refVal.Type()
+	if refType.Implements(typeOfEncoder) || refType == typeOfJsObject || refVal.Kind() == reflect.Interface {
+		snapshot[path] = encodedSnapshot(refVal)
+		return
+	}
+
+	switch refVal.Kind() {
+	case reflect.Ptr:
+		if refVal.IsNil() {
+			snapshot[path] = nil
+			return
+		}
+
+		snapshotValue(snapshot, path, refVal.Elem())
+	case reflect.Slice:
+		snapshot[path+lengthKeySuffix] = refVal.Len()
+		for i := 0; i < refVal.Len(); i++ {
+			snapshotValue(snapshot, path+"."+strconv.Itoa(i), refVal.Index(i))
+		}
+	case reflect.Struct:
+		if refType == typeOfTime {
+			snapshot[path] = refVal.Interface().(time.Time).UnixNano()
+			return
+		}
+
+		snapshotStruct(snapshot, path, refVal)
+	default:
+		snapshot[path] = refVal.Interface()
+	}
+}
+
+// snapshotStruct records the exported fields of the struct refVal, embedded structs are flattened the same way encodeStruct does
+func snapshotStruct(snapshot map[string]interface{}, path string, refVal reflect.Value) {
+	refType := refVal.Type()
+	for i := 0; i < refType.NumField(); i++ {
+		field := refType.Field(i)
+		if !isFieldExported(field.Name) {
+			continue
+		}
+
+		fieldVal := refVal.Field(i)
+		if field.Anonymous && field.Type != typeOfPtrBindProto {
+			if field.Type.Kind() == reflect.Ptr && field.Type.Elem().Kind() == reflect.Struct {
+				if !fieldVal.IsNil() {
+					snapshotStruct(snapshot, path, fieldVal.Elem())
+				}
+				continue
+			}
+			if field.Type.Kind() == reflect.Struct {
+				snapshotStruct(snapshot, path, fieldVal)
+				continue
+			}
+		}
+
+		snapshotValue(snapshot, path+"."+getFieldJsName(field), fieldVal)
+	}
+}
+
+// encodedSnapshot returns a comparable representation of values the Go side can't look into, such as interfaces and custom encoders
+// The value is encoded and serialized to JSON where possible, values that can't be serialized are compared by identity
+func encodedSnapshot(refVal reflect.Value) (snapshot interface{}) {
+	jsObj, _ := encodeRaw(refVal)
+	if jsObj == nil || jsObj == js.Undefined {
+		return nil
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			snapshot = jsObj
+		}
+	}()
+
+	return js.Global.Get("JSON").Call("stringify", jsObj).String()
+}
+
+// diffSnapshots returns the sorted paths whose value differs between the two snapshots
+func diffSnapshots(old, current map[string]interface{}) []string {
+	changedPaths := make(map[string]bool)
+	for key, val := range current {
+		if oldVal, ok := old[key]; !ok || oldVal != val {
+			changedPaths[strings.TrimSuffix(key, lengthKeySuffix)] = true
+		}
+	}
+	for key := range old {
+		if _, ok := current[key]; !ok {
+			changedPaths[strings.TrimSuffix(key, lengthKeySuffix)] = true
+		}
+	}
+
+	changed := make([]string, 0, len(changedPaths))
+	for path := range changedPaths {
+		changed = append(changed, path)
+	}
+	sort.Strings(changed)
+
+	return changed
+}
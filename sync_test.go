@@ -0,0 +1,86 @@
+/*
+Copyright 2015 Palm Stone Games, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package polymer
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDiffSnapshots(t *testing.T) {
+	tests := []struct {
+		old, current map[string]interface{}
+		want         []string
+	}{
+		{map[string]interface{}{"foo": 1}, map[string]interface{}{"foo": 1}, []string{}},
+		{map[string]interface{}{"foo": 1}, map[string]interface{}{"foo": 2}, []string{"foo"}},
+		{map[string]interface{}{"foo": 1}, map[string]interface{}{"foo": 1, "bar": "x"}, []string{"bar"}},
+		{map[string]interface{}{"foo": 1, "bar": "x"}, map[string]interface{}{"foo": 1}, []string{"bar"}},
+		{map[string]interface{}{"b": 1, "a": 1}, map[string]interface{}{"b": 2, "a": 2}, []string{"a", "b"}},
+		{
+			map[string]interface{}{"items.#length": 1, "items.0": "a"},
+			map[string]interface{}{"items.#length": 2, "items.0": "a", "items.1": "b"},
+			[]string{"items", "items.1"},
+		},
+	}
+
+	for _, test := range tests {
+		if got := diffSnapshots(test.old, test.current); !reflect.DeepEqual(got, test.want) {
+			t.Errorf("diffSnapshots(%v, %v) = %q, want %q", test.old, test.current, got, test.want)
+		}
+	}
+}
+
+type snapshotInner struct {
+	Name string
+}
+
+type SnapshotMixin struct {
+	Mixed int
+}
+
+type snapshotOuter struct {
+	SnapshotMixin
+	Inner    snapshotInner
+	InnerPtr *snapshotInner
+	Items    []int
+	hidden   int
+}
+
+func TestSnapshotValue(t *testing.T) {
+	val := snapshotOuter{
+		SnapshotMixin: SnapshotMixin{Mixed: 1},
+		Inner:         snapshotInner{Name: "a"},
+		Items:         []int{4, 5},
+		hidden:        3,
+	}
+
+	want := map[string]interface{}{
+		"root.mixed":         1,
+		"root.inner.name":    "a",
+		"root.innerPtr":      nil,
+		"root.items.#length": 2,
+		"root.items.0":       4,
+		"root.items.1":       5,
+	}
+
+	got := make(map[string]interface{})
+	snapshotValue(got, "root", reflect.ValueOf(val))
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("snapshotValue() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,191 @@
+/*
+Copyright 2015 Palm Stone Games, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package polymer
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// fieldTag holds the parsed contents of a `polymer:"..."` struct tag
+type fieldTag struct {
+	bind               bool
+	handler            bool
+	reflectToAttribute bool
+	readOnly           bool
+	observer           string
+	computed           string
+	name               string
+	notify             bool
+	jsType             string
+	value              string
+	listen             string
+
+	// unknown holds all options that weren't recognized, these are reported by Validate
+	unknown []string
+	// invalid holds all flag options with a value other than true or false, these are reported by Validate
+	invalid []string
+}
+
+// bound returns true if the field is exposed as a polymer property, either through bind or computed
+func (tag fieldTag) bound() bool {
+	return tag.bind || tag.computed != ""
+}
+
+// parseTag parses the polymer struct tag on the passed field
+// Options are separated by commas and can either be flags (e.g. `readOnly`) or key/value pairs (e.g. `observer=OnFoo`)
+// Commas inside parentheses do not separate options, so `computed=ComputeFoo(a,b)` is parsed as a single option
+func parseTag(field reflect.StructField) fieldTag {
+	tag := fieldTag{notify: true}
+
+	tagText := field.Tag.Get("polymer")
+	if tagText == "" {
+		return tag
+	}
+
+	for _, option := range splitTag(tagText) {
+		key, value := option, ""
+		if i := strings.Index(option, "="); i != -1 {
+			key, value = option[:i], option[i+1:]
+		}
+
+		switch key {
+		case "bind":
+			tag.bind = true
+		case "handler":
+			tag.handler = true
+		case "reflectToAttribute":
+			tag.reflectToAttribute = tag.parseFlag(option, value)
+		case "readOnly":
+			tag.readOnly = tag.parseFlag(option, value)
+		case "notify":
+			tag.notify = tag.parseFlag(option, value)
+		case "observer":
+			tag.observer = value
+		case "computed":
+			tag.computed = value
+		case "name":
+			tag.name = value
+		case "attribute":
+			tag.name = dashToCamelCase(value)
+		case "type":
+			tag.jsType = value
+		case "value":
+			tag.value = value
+		case "listen":
+			tag.listen = value
+		default:
+			tag.unknown = append(tag.unknown, option)
+		}
+	}
+
+	return tag
+}
+
+// parseFlag parses the value of a flag option, flags without a value are considered to be set
+// Values other than true and false are recorded as invalid and leave the flag unset
+func (tag *fieldTag) parseFlag(option, value string) bool {
+	switch value {
+	case "", "true":
+		return true
+	case "false":
+		return false
+	default:
+		tag.invalid = append(tag.invalid, option)
+		return false
+	}
+}
+
+// splitTag splits the tag text on all commas that aren't enclosed in parentheses, brackets, braces or double quotes
+// This allows both computed expressions and JSON default values to contain commas
+func splitTag(tagText string) []string {
+	var options []string
+
+	depth := 0
+	start := 0
+	inString := false
+	escaped := false
+	for i, ch := range tagText {
+		if inString {
+			switch {
+			case escaped:
+				escaped = false
+			case ch == '\\':
+				escaped = true
+			case ch == '"':
+				inString = false
+			}
+			continue
+		}
+
+		switch ch {
+		case '"':
+			inString = true
+		case '(', '[', '{':
+			depth++
+		case ')', ']', '}':
+			depth--
+		case ',':
+			if depth == 0 {
+				options = append(options, tagText[start:i])
+				start = i + 1
+			}
+		}
+	}
+
+	return append(options, tagText[start:])
+}
+
+// dashToCamelCase converts an attribute name such as `foo-bar` to the corresponding property name `fooBar`
+func dashToCamelCase(name string) string {
+	parts := strings.Split(name, "-")
+	for i := 1; i < len(parts); i++ {
+		if parts[i] != "" {
+			parts[i] = strings.ToUpper(parts[i][:1]) + parts[i][1:]
+		}
+	}
+
+	return strings.Join(parts, "")
+}
+
+// parseComputedExpr validates the computed expression of a field and converts it to its javascript equivalent
+// The expression has the form `ComputeFoo(arg1,arg2)`, where ComputeFoo is a Compute method on rootType and the arguments are property paths
+func parseComputedExpr(rootType reflect.Type, field reflect.StructField, expr string) (string, error) {
+	name, args, ok := splitMethodExpr(expr)
+	if !ok {
+		return "", fmt.Errorf("Computed expression '%v' for field %v should have the form ComputeFoo(arg1,arg2)", expr, field.Name)
+	}
+
+	for _, method := range parseComputes(rootType) {
+		if method.Name == name || getJsName(method.Name) == name {
+			return getJsName(method.Name) + args, nil
+		}
+	}
+
+	return "", fmt.Errorf("Computed expression '%v' for field %v refers to %v, which is not a Compute method on %v", expr, field.Name, name, rootType)
+}
+
+// splitMethodExpr splits an expression of the form `foo(arg1,arg2)` into the method name and the parenthesized arguments
+func splitMethodExpr(expr string) (name string, args string, ok bool) {
+	openIndex := strings.Index(expr, "(")
+	if openIndex == -1 || !strings.HasSuffix(expr, ")") {
+		return "", "", false
+	}
+
+	return strings.TrimSpace(expr[:openIndex]), expr[openIndex:], true
+}
@@ -0,0 +1,143 @@
+/*
+Copyright 2015 Palm Stone Games, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package polymer
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseTag(t *testing.T) {
+	tests := []struct {
+		tag  reflect.StructTag
+		want fieldTag
+	}{
+		{``, fieldTag{notify: true}},
+		{`polymer:"bind"`, fieldTag{bind: true, notify: true}},
+		{`polymer:"bind,observer=OnFoo"`, fieldTag{bind: true, notify: true, observer: "OnFoo"}},
+		{`polymer:"bind,notify=false"`, fieldTag{bind: true}},
+		{`polymer:"bind,readOnly,reflectToAttribute=true"`, fieldTag{bind: true, notify: true, readOnly: true, reflectToAttribute: true}},
+		{`polymer:"handler,listen=tap"`, fieldTag{handler: true, notify: true, listen: "tap"}},
+		{`polymer:"bind,bogus"`, fieldTag{bind: true, notify: true, unknown: []string{"bogus"}}},
+		{`polymer:"bind,readOnly=yes"`, fieldTag{bind: true, notify: true, invalid: []string{"readOnly=yes"}}},
+	}
+
+	for _, test := range tests {
+		got := parseTag(reflect.StructField{Name: "Foo", Tag: test.tag})
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("parseTag(%v) = %+v, want %+v", test.tag, got, test.want)
+		}
+	}
+}
+
+func TestSplitTag(t *testing.T) {
+	tests := []struct {
+		text string
+		want []string
+	}{
+		{"bind", []string{"bind"}},
+		{"bind,notify=false", []string{"bind", "notify=false"}},
+		{"computed=ComputeFoo(a,b),readOnly", []string{"computed=ComputeFoo(a,b)", "readOnly"}},
+		{`value=[1,2],bind`, []string{"value=[1,2]", "bind"}},
+		{`value={"a":1,"b":[2,3]},bind`, []string{`value={"a":1,"b":[2,3]}`, "bind"}},
+		{`value="a,b",bind`, []string{`value="a,b"`, "bind"}},
+		{`value="a\",(b",bind`, []string{`value="a\",(b"`, "bind"}},
+		{"bind,", []string{"bind", ""}},
+	}
+
+	for _, test := range tests {
+		if got := splitTag(test.text); !reflect.DeepEqual(got, test.want) {
+			t.Errorf("splitTag(%q) = %q, want %q", test.text, got, test.want)
+		}
+	}
+}
+
+type computedExprProto struct {
+	*Proto
+}
+
+func (p *computedExprProto) ComputeFullName(first, last string) string { return first + " " + last }
+
+func TestParseComputedExpr(t *testing.T) {
+	tests := []struct {
+		expr    string
+		want    string
+		wantErr bool
+	}{
+		{"ComputeFullName(first,last)", "computeFullName(first,last)", false},
+		{"computeFullName(first,last)", "computeFullName(first,last)", false},
+		{"ComputeFullName()", "computeFullName()", false},
+		{"ComputeMissing(first)", "", true},
+		{"ComputeFullName", "", true},
+		{"ComputeFullName(first", "", true},
+	}
+
+	rootType := reflect.TypeOf(&computedExprProto{})
+	field := reflect.StructField{Name: "FullName"}
+	for _, test := range tests {
+		got, err := parseComputedExpr(rootType, field, test.expr)
+		if (err != nil) != test.wantErr {
+			t.Errorf("parseComputedExpr(%q) returned error %v, want error: %v", test.expr, err, test.wantErr)
+			continue
+		}
+		if got != test.want {
+			t.Errorf("parseComputedExpr(%q) = %q, want %q", test.expr, got, test.want)
+		}
+	}
+}
+
+func TestDashToCamelCase(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"foo", "foo"},
+		{"foo-bar", "fooBar"},
+		{"foo-bar-baz", "fooBarBaz"},
+		{"foo--bar", "fooBar"},
+		{"foo-", "foo"},
+		{"", ""},
+	}
+
+	for _, test := range tests {
+		if got := dashToCamelCase(test.name); got != test.want {
+			t.Errorf("dashToCamelCase(%q) = %q, want %q", test.name, got, test.want)
+		}
+	}
+}
+
+func TestSplitMethodExpr(t *testing.T) {
+	tests := []struct {
+		expr       string
+		name, args string
+		ok         bool
+	}{
+		{"ObserveFoo(a,b)", "ObserveFoo", "(a,b)", true},
+		{"ObserveFoo(items.*)", "ObserveFoo", "(items.*)", true},
+		{" ObserveFoo ()", "ObserveFoo", "()", true},
+		{"ObserveFoo", "", "", false},
+		{"ObserveFoo(a", "", "", false},
+		{"ObserveFoo)", "", "", false},
+	}
+
+	for _, test := range tests {
+		name, args, ok := splitMethodExpr(test.expr)
+		if name != test.name || args != test.args || ok != test.ok {
+			t.Errorf("splitMethodExpr(%q) = %q, %q, %v, want %q, %q, %v", test.expr, name, args, ok, test.name, test.args, test.ok)
+		}
+	}
+}
@@ -0,0 +1,152 @@
+/*
+Copyright 2015 Palm Stone Games, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package polymer
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/gopherjs/gopherjs/js"
+)
+
+// ValidationError is returned by Validate and RegisterE, it lists every problem found with an element definition
+type ValidationError struct {
+	// Name is the tag name of the element, or the name of the behavior
+	Name     string
+	Problems []string
+}
+
+func (err *ValidationError) Error() string {
+	if len(err.Problems) == 1 {
+		return fmt.Sprintf("%v: %v", err.Name, err.Problems[0])
+	}
+
+	return fmt.Sprintf("%v: %v problems found:\n  - %v", err.Name, len(err.Problems), strings.Join(err.Problems, "\n  - "))
+}
+
+// reservedTagNames holds the names that contain a dash, but can't be used for custom elements according to the custom elements spec
+var reservedTagNames = map[string]bool{
+	"annotation-xml":   true,
+	"color-profile":    true,
+	"font-face":        true,
+	"font-face-src":    true,
+	"font-face-uri":    true,
+	"font-face-format": true,
+	"font-face-name":   true,
+	"missing-glyph":    true,
+}
+
+// Validate checks the definition of an element the same way RegisterE does, without registering it
+// The returned error is a *ValidationError listing every problem found, or nil if the element can be registered
+func Validate(tagName string, proto Interface, customAttrs ...CustomRegistrationAttr) error {
+	if _, err := newElementDefinition(tagName, proto, customAttrs, true); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// validateTagName checks tagName, which contains a dash, is a valid custom element name according to the custom elements spec
+func validateTagName(tagName string) error {
+	if tagName[0] < 'a' || tagName[0] > 'z' {
+		return fmt.Errorf("Tagname '%v' must start with a lowercase ascii letter", tagName)
+	}
+	if reservedTagNames[tagName] {
+		return fmt.Errorf("Tagname '%v' is reserved and can't be used for custom elements", tagName)
+	}
+
+	for _, ch := range tagName {
+		switch {
+		case ch >= 'a' && ch <= 'z', ch >= '0' && ch <= '9', ch == '-', ch == '.', ch == '_', ch >= 0xB7:
+		default:
+			return fmt.Errorf("Tagname '%v' contains the invalid character '%c'", tagName, ch)
+		}
+	}
+
+	return nil
+}
+
+// validateFields records findings with the struct tags and types of the fields of def
+func validateFields(def *ElementDefinition) {
+	for _, field := range parseFields(def.refType.Elem()) {
+		tag := parseTag(field)
+		for _, option := range tag.unknown {
+			def.findingf("Unknown option '%v' in the polymer tag of field %v", option, field.Name)
+		}
+		for _, option := range tag.invalid {
+			def.findingf("Invalid flag value in option '%v' in the polymer tag of field %v, expected true or false", option, field.Name)
+		}
+
+		if tag.bound() && !supportedType(field.Type, make(map[reflect.Type]bool)) {
+			def.findingf("Field %v has type %v, which can't be synced with polymer", field.Name, field.Type)
+		}
+		if tag.handler && field.Type.Kind() != reflect.Chan {
+			def.findingf("Field %v is tagged as handler, but isn't a channel", field.Name)
+		}
+		if tag.listen != "" && !tag.handler {
+			def.findingf("Field %v has a listen option, but isn't tagged as handler", field.Name)
+		}
+	}
+}
+
+// validateBehaviors records problems with behaviors that can't be passed to polymer
+func validateBehaviors(def *ElementDefinition, behaviors []interface{}) {
+	for _, behavior := range behaviors {
+		switch behavior.(type) {
+		case string, *ElementDefinition, *js.Object:
+		default:
+			def.problemf("Don't know what to do with behavior of type %T", behavior)
+		}
+	}
+}
+
+// supportedType returns true if values of type t can be encoded to and decoded from javascript
+func supportedType(t reflect.Type, seen map[reflect.Type]bool) bool {
+	if seen[t] {
+		return true
+	}
+	seen[t] = true
+
+	if t.Implements(typeOfEncoder) || reflect.PtrTo(t).Implements(typeOfDecoder) {
+		return true
+	}
+
+	switch t.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64, reflect.String, reflect.Bool, reflect.Interface:
+		return true
+	case reflect.Slice, reflect.Ptr:
+		return t == typeOfJsObject || supportedType(t.Elem(), seen)
+	case reflect.Struct:
+		if t == typeOfTime {
+			return true
+		}
+
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			if field.PkgPath == "" && !supportedType(field.Type, seen) {
+				return false
+			}
+		}
+
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,43 @@
+/*
+Copyright 2015 Palm Stone Games, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package polymer
+
+import "testing"
+
+func TestValidateTagName(t *testing.T) {
+	tests := []struct {
+		tagName string
+		valid   bool
+	}{
+		{"my-element", true},
+		{"x-foo.bar_baz-2", true},
+		{"my-élément", true},
+		{"My-element", false},
+		{"1-element", false},
+		{"-element", false},
+		{"my-Element", false},
+		{"my element-x", false},
+		{"font-face", false},
+		{"annotation-xml", false},
+	}
+
+	for _, test := range tests {
+		if err := validateTagName(test.tagName); (err == nil) != test.valid {
+			t.Errorf("validateTagName(%q) returned %v, want valid: %v", test.tagName, err, test.valid)
+		}
+	}
+}